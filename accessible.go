@@ -0,0 +1,240 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+// describeCard names a card along with what it does in the dungeon
+func (m model) describeCard(c deck.Card) string {
+	switch c.Suit {
+	case deck.Heart:
+		return fmt.Sprintf("%s potion, heals %d", c, c.Rank)
+	case deck.Diamond:
+		return fmt.Sprintf("%s weapon, power %d", c, c.Rank)
+	case deck.Joker:
+		return fmt.Sprintf("%s, a potion or weapon of %d, or a way out of the room", c, m.rules.JokerValue)
+	default:
+		return fmt.Sprintf("%s monster, strength %d", c, m.rules.strength(c))
+	}
+}
+
+// describeWeapon states the equipped weapon and what it can still hit
+func (m model) describeWeapon() string {
+	if !m.weapon.equipped() {
+		return "No weapon."
+	}
+
+	s := fmt.Sprintf("Weapon: %s, power %d", m.weapon.card, m.weaponPower())
+	if limit, limited := m.weaponLimit(); limited {
+		last := m.weapon.slain[len(m.weapon.slain)-1]
+		s += fmt.Sprintf(", last slain %s, can hit %s.", last, m.rules.limitText(limit))
+	} else {
+		s += ", unused, can hit any monster."
+	}
+	return s
+}
+
+// options lists what can be chosen in the current view, numbered from 1
+func (m model) options() []string {
+	var options []string
+	switch m.viewState {
+	case viewStateRoom:
+		for _, c := range m.room {
+			options = append(options, m.describeCard(c))
+		}
+		if m.skippable {
+			options = append(options, "Skip this room")
+		}
+	case viewStateAttack:
+		c := m.room[m.selection]
+		options = append(options,
+			fmt.Sprintf("Fight with fists, life %d to %d", m.life, max(0, m.life-m.fistDamage(c))),
+			fmt.Sprintf("Fight with weapon, life %d to %d, weapon then only hits %s", m.life, max(0, m.life-m.weaponDamage(c)), m.rules.limitText(m.rules.strength(c))),
+			"Cancel",
+		)
+	case viewStateJoker:
+		value := m.rules.JokerValue
+		options = append(options,
+			fmt.Sprintf("Drink as a potion, life %d to %d", m.life, min(m.rules.MaxLife, m.life+value)),
+			fmt.Sprintf("Wield as a weapon of power %d", value),
+			"Flee the room",
+			"Cancel",
+		)
+	case viewStateRelic:
+		for _, name := range m.relicOffers {
+			options = append(options, fmt.Sprintf("Take the %s: %s", name, allRelics[name].description))
+		}
+		options = append(options, "Take nothing")
+	}
+	for i := range options {
+		options[i] = fmt.Sprintf("%d. %s", i+1, options[i])
+	}
+	return options
+}
+
+// selected is the option the cursor is on
+func (m model) selected() int {
+	switch m.viewState {
+	case viewStateAttack:
+		return m.attackTypeSelection
+	case viewStateJoker:
+		return m.jokerSelection
+	case viewStateRelic:
+		return m.relicSelection
+	}
+	return m.selection
+}
+
+// describe spells out the whole state of the game in plain sentences,
+// including where the cursor is
+func (m model) describe() string {
+	s := m.describeState()
+	options := m.options()
+	if i := m.selected(); i < len(options) {
+		s += "\nSelected: " + options[i] + "."
+	}
+	return s
+}
+
+// describeState spells out the life, weapon and choices on offer
+func (m model) describeState() string {
+	var lines []string
+	if m.inCampaign() {
+		lines = append(lines, fmt.Sprintf("Depth %d.", m.campaign.depth))
+	}
+	if m.inPuzzle() {
+		lines = append(lines, fmt.Sprintf("Puzzle %s. Goal: %s.", m.puzzle.name, m.puzzle.goal))
+	}
+	lines = append(lines, fmt.Sprintf("Life %d of %d. %d cards left in the dungeon.", m.life, m.rules.MaxLife, len(m.dungeon)))
+	lines = append(lines, m.describeWeapon())
+	if len(m.relics) > 0 {
+		lines = append(lines, fmt.Sprintf("Relics: %s.", m.relicsView()))
+	}
+
+	options := m.options()
+	switch m.viewState {
+	case viewStateRoom:
+		lines = append(lines, "Room: "+strings.Join(options, "; ")+".")
+	case viewStateAttack:
+		lines = append(lines, fmt.Sprintf("Fight the %s: %s.", m.room[m.selection], strings.Join(options, "; ")))
+	case viewStateJoker:
+		lines = append(lines, fmt.Sprintf("Play the %s: %s.", m.room[m.selection], strings.Join(options, "; ")))
+	case viewStateRelic:
+		lines = append(lines, "Relics on offer: "+strings.Join(options, "; ")+".")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// announce describes what just happened in plain sentences
+func (m model) announce(events []event) string {
+	var sentences []string
+	for _, e := range events {
+		switch e.Kind {
+		case eventPotion:
+			s := fmt.Sprintf("You drank the %s and healed %d", e.Card, e.After-e.Before)
+			if wasted := m.rules.value(e.Card) - (e.After - e.Before); wasted > 0 {
+				s += fmt.Sprintf(", %d was wasted", wasted)
+			}
+			sentences = append(sentences, s+".")
+		case eventEquip:
+			sentences = append(sentences, fmt.Sprintf("You equipped the %s.", e.Card))
+		case eventFists:
+			sentences = append(sentences, fmt.Sprintf("You fought the %s with your fists and took %d damage.", e.Card, e.Before-e.After))
+		case eventWeapon:
+			sentences = append(sentences, fmt.Sprintf("You slew the %s with your weapon and took %d damage.", e.Card, e.Before-e.After))
+		case eventSkip:
+			sentences = append(sentences, "You skipped the room.")
+		case eventFlee:
+			sentences = append(sentences, fmt.Sprintf("You fled the room with the %s.", e.Card))
+		case eventCleared:
+			sentences = append(sentences, "Room cleared.")
+		case eventDescend:
+			sentences = append(sentences, "Dungeon cleared, you descend deeper.")
+		case eventRelic:
+			sentences = append(sentences, fmt.Sprintf("The %s heals you %d.", e.Relic, e.After-e.Before))
+		case eventTakeRelic:
+			if e.Relic != "" {
+				sentences = append(sentences, fmt.Sprintf("You took the %s.", e.Relic))
+			}
+		}
+	}
+	if len(events) > 0 {
+		sentences = append(sentences, fmt.Sprintf("Life is now %d.", events[len(events)-1].After))
+	}
+	return strings.Join(sentences, " ")
+}
+
+// describeGameOver sums up a finished game in plain sentences
+func (m model) describeGameOver() string {
+	lines := []string{"Game over."}
+	if m.won() && !m.inPuzzle() || m.inPuzzle() && m.solved() {
+		lines[0] = "Victory."
+	}
+	if m.inPuzzle() {
+		lines = append(lines, fmt.Sprintf("Puzzle %s. Goal: %s, %s.", m.puzzle.name, m.puzzle.goal, map[bool]string{true: "solved", false: "not solved"}[m.solved()]))
+	}
+
+	life, penalty, bonus := m.scoreBreakdown()
+	switch {
+	case penalty > 0:
+		lines = append(lines, fmt.Sprintf("Score %d, life %d minus %d for the monsters left.", m.score(), life, penalty))
+	case bonus > 0:
+		lines = append(lines, fmt.Sprintf("Score %d, life %d plus %d for the last potion.", m.score(), life, bonus))
+	default:
+		lines = append(lines, fmt.Sprintf("Score %d.", m.score()))
+	}
+	if m.inCampaign() {
+		lines = append(lines, fmt.Sprintf("Depth reached %d, campaign score %d. Relics: %s.", m.campaign.depth, m.campaignScore(), m.relicsView()))
+	}
+	if !m.inPuzzle() {
+		lines = append(lines, fmt.Sprintf("Seed %d.", m.seed))
+	}
+	lines = append(lines, fmt.Sprintf("Rules: %s.", m.rules))
+
+	sum := m.summary()
+	lines = append(lines,
+		fmt.Sprintf("Rooms cleared %d, skipped %d.", sum.roomsCleared, sum.roomsSkipped),
+		fmt.Sprintf("Potions drunk %d, healing wasted %d.", sum.potionsDrunk, sum.healingWasted),
+		fmt.Sprintf("Monsters slain %d with a weapon, %d with fists. Biggest hit taken %d.", sum.weaponKills, sum.fistKills, sum.biggestHit),
+		"Press enter to play again, or q to quit.",
+	)
+	return strings.Join(lines, "\n")
+}
+
+// narrate says what changed since prev. It's printed below whatever was
+// printed before, so a screen reader reads each line once and nothing is ever
+// redrawn.
+func (m *model) narrate(prev model) string {
+	var lines []string
+	if m.announcement != "" {
+		lines = append(lines, m.announcement)
+		m.announcement = ""
+	}
+	if m.err != nil && prev.err == nil {
+		lines = append(lines, fmt.Sprintf("Couldn't record this game: %v.", m.err))
+	}
+
+	switch {
+	case m.viewState == viewStateGameOver:
+		if prev.viewState != viewStateGameOver {
+			lines = append(lines, m.describeGameOver())
+		}
+	case m.confirming && !prev.confirming:
+		lines = append(lines, "This move will kill you. Press enter again to confirm.")
+	case m.describeState() != prev.describeState():
+		lines = append(lines, m.describe())
+	case m.selected() != prev.selected():
+		if options, i := m.options(), m.selected(); i < len(options) {
+			lines = append(lines, "Selected: "+options[i]+".")
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// accessibleKeys is printed once when the game starts
+const accessibleKeys = "Keys: up and down to choose, enter to play, q to save and quit."
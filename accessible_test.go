@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+func TestDescribe(t *testing.T) {
+	m := model{
+		rules:   official,
+		life:    14,
+		dungeon: testDungeon(),
+		room: []deck.Card{
+			{Suit: deck.Club, Rank: 7},
+			{Suit: deck.Heart, Rank: 5},
+			{Suit: deck.Spade, Rank: deck.Ace},
+		},
+		weapon: weapon{
+			card:  deck.Card{Suit: deck.Diamond, Rank: 6},
+			slain: []deck.Card{{Suit: deck.Spade, Rank: 9}},
+		},
+		selection: 1,
+		skippable: true,
+		viewState: viewStateRoom,
+	}
+
+	expected := "Life 14 of 20. 8 cards left in the dungeon.\n" +
+		"Weapon: Six of Diamonds, power 6, last slain Nine of Spades, can hit monsters of strength 9 or less.\n" +
+		"Room: 1. Seven of Clubs monster, strength 7; 2. Five of Hearts potion, heals 5; 3. Ace of Spades monster, strength 14; 4. Skip this room.\n" +
+		"Selected: 2. Five of Hearts potion, heals 5."
+	if s := m.describe(); s != expected {
+		t.Errorf("expected description to be\n%s\ngot\n%s", expected, s)
+	}
+
+	m.selection = 0
+	m.viewState = viewStateAttack
+	m.attackTypeSelection = int(withWeapon)
+	expected = "Life 14 of 20. 8 cards left in the dungeon.\n" +
+		"Weapon: Six of Diamonds, power 6, last slain Nine of Spades, can hit monsters of strength 9 or less.\n" +
+		"Fight the Seven of Clubs: 1. Fight with fists, life 14 to 7; 2. Fight with weapon, life 14 to 13, weapon then only hits monsters of strength 7 or less; 3. Cancel.\n" +
+		"Selected: 2. Fight with weapon, life 14 to 13, weapon then only hits monsters of strength 7 or less."
+	if s := m.describe(); s != expected {
+		t.Errorf("expected description to be\n%s\ngot\n%s", expected, s)
+	}
+}
+
+func TestAnnounce(t *testing.T) {
+	m := model{rules: official, life: 17}
+	m.usePotion(deck.Card{Suit: deck.Heart, Rank: 5})
+	m.attackWithFists(deck.Card{Suit: deck.Club, Rank: 7})
+	m.log(eventCleared, deck.Card{}, m.life)
+
+	expected := "You drank the Five of Hearts and healed 3, 2 was wasted. " +
+		"You fought the Seven of Clubs with your fists and took 7 damage. " +
+		"Room cleared. Life is now 13."
+	if s := m.announce(m.events); s != expected {
+		t.Errorf("expected announcement to be %q, got %q", expected, s)
+	}
+
+	if s := m.announce(nil); s != "" {
+		t.Errorf("expected no announcement without events, got %q", s)
+	}
+}
+
+func TestNarrate(t *testing.T) {
+	s := defaultSettings()
+	s.accessible = true
+	s.confirmLethal = false
+	s.dataDir = t.TempDir()
+	m := newGame(1, official, s)
+
+	press := func(key tea.KeyType) string {
+		t.Helper()
+		prev := m
+		next, _ := m.update(tea.KeyMsg{Type: key})
+		m = next.(model)
+		return m.narrate(prev)
+	}
+
+	// Moving the cursor only says what's selected
+	options := m.options()
+	if said := press(tea.KeyDown); said != "Selected: "+options[1]+"." {
+		t.Errorf("expected moving down to select %q, got %q", options[1], said)
+	}
+
+	// Playing a card says what happened and the whole state, once
+	said := press(tea.KeyEnter)
+	if !strings.HasPrefix(said, m.announce(m.events)) || !strings.HasSuffix(said, m.describe()) {
+		t.Errorf("expected playing to announce it and describe the room, got\n%s", said)
+	}
+	if said := press(tea.KeyDown); strings.Contains(said, "Life is now") {
+		t.Errorf("expected the announcement only once, got %q", said)
+	}
+
+	for m.viewState != viewStateGameOver {
+		prev := m
+		if err := m.play(botMove(m)); err != nil {
+			t.Fatal(err)
+		}
+		said = m.narrate(prev)
+	}
+	if !strings.HasSuffix(said, m.describeGameOver()) {
+		t.Errorf("expected the game over summary when the game ends, got\n%s", said)
+	}
+	said = press(tea.KeyUp)
+	if said != "" {
+		t.Errorf("expected nothing new to say after the game, got %q", said)
+	}
+	summary := m.describeGameOver()
+	for _, r := range summary {
+		if r >= 0x80 {
+			t.Fatalf("expected the game over summary in plain text, got %q in\n%s", r, summary)
+		}
+	}
+	if !strings.Contains(summary, fmt.Sprintf("Seed %d.", m.seed)) {
+		t.Errorf("expected the summary to give the seed, got\n%s", summary)
+	}
+}
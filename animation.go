@@ -0,0 +1,80 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// animationFrame is how long each step of an animation is shown
+const animationFrame = 80 * time.Millisecond
+
+// flashFrames is how many frames the header flashes after taking damage
+const flashFrames = 4
+
+// tickMsg advances any running animations by a frame
+type tickMsg struct{}
+
+func tick() tea.Cmd {
+	return tea.Tick(animationFrame, func(time.Time) tea.Msg {
+		return tickMsg{}
+	})
+}
+
+// animating reports whether anything on screen hasn't caught up with the
+// game yet
+func (m model) animating() bool {
+	if !m.settings.animations || m.settings.accessible {
+		return false
+	}
+	return m.shownLife != m.life || m.flash > 0 || m.dealt < len(m.room)
+}
+
+// animate starts the animation ticks if something needs animating and they
+// aren't already running
+func (m *model) animate() tea.Cmd {
+	if m.ticking || !m.animating() {
+		return nil
+	}
+	m.ticking = true
+	return tick()
+}
+
+// step moves every running animation on by a frame
+func (m *model) step() tea.Cmd {
+	switch {
+	case m.shownLife < m.life:
+		m.shownLife++
+	case m.shownLife > m.life:
+		m.shownLife--
+	}
+	m.flash = max(0, m.flash-1)
+	m.dealt = min(m.dealt+1, len(m.room))
+
+	if !m.animating() {
+		m.ticking = false
+		return nil
+	}
+
+	// The ticks may have been started by Init, which couldn't mark them
+	// running
+	m.ticking = true
+	return tick()
+}
+
+// lifeView is the life shown in the header, which counts towards the real
+// life when animations are on
+func (m model) lifeView() int {
+	if !m.settings.animations || m.settings.accessible {
+		return m.life
+	}
+	return m.shownLife
+}
+
+// dealtView is how many cards of the room have been dealt onto the screen
+func (m model) dealtView() int {
+	if !m.settings.animations || m.settings.accessible {
+		return len(m.room)
+	}
+	return min(m.dealt, len(m.room))
+}
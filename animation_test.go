@@ -0,0 +1,99 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+func TestAnimate(t *testing.T) {
+	m := model{
+		rules:     official,
+		settings:  settings{animations: true},
+		life:      20,
+		shownLife: 20,
+		dungeon:   testDungeon(),
+		room:      []deck.Card{},
+	}
+	m.drawToRoom(4)
+	m.life = 17
+
+	if m.dealtView() != 0 {
+		t.Errorf("expected no cards to be dealt yet, got %d", m.dealtView())
+	}
+	if m.animate() == nil {
+		t.Fatal("expected animation to start")
+	}
+	if m.animate() != nil {
+		t.Error("expected animation not to start twice")
+	}
+
+	for i := 0; i < 3; i++ {
+		if m.step() == nil {
+			t.Fatalf("expected animation to still be running after %d steps", i+1)
+		}
+	}
+	if m.lifeView() != 17 {
+		t.Errorf("expected shown life to be 17 after 3 steps, got %d", m.lifeView())
+	}
+	if m.dealtView() != 3 {
+		t.Errorf("expected 3 cards to be dealt after 3 steps, got %d", m.dealtView())
+	}
+
+	if m.step() != nil {
+		t.Error("expected animation to stop once everything caught up")
+	}
+	if m.ticking {
+		t.Error("expected ticking to stop once everything caught up")
+	}
+	if m.dealtView() != 4 {
+		t.Errorf("expected all cards to be dealt, got %d", m.dealtView())
+	}
+}
+
+func TestAnimationsDisabled(t *testing.T) {
+	m := model{
+		rules:   official,
+		life:    12,
+		dungeon: testDungeon(),
+		room:    []deck.Card{},
+	}
+	m.drawToRoom(4)
+
+	if m.animate() != nil {
+		t.Error("expected no animation when animations are disabled")
+	}
+	if m.lifeView() != 12 {
+		t.Errorf("expected shown life to be 12, got %d", m.lifeView())
+	}
+	if m.dealtView() != 4 {
+		t.Errorf("expected all 4 cards to be dealt, got %d", m.dealtView())
+	}
+}
+
+// Ticks started by Init mustn't be doubled up by a key pressed while the
+// room is being dealt
+func TestInitTicksOnce(t *testing.T) {
+	s := defaultSettings()
+	s.animations = true
+	m := newGame(1, official, s)
+	if m.Init() == nil {
+		t.Fatal("expected the deal to start animating")
+	}
+
+	next, cmd := m.Update(tickMsg{})
+	if cmd == nil {
+		t.Fatal("expected the deal to still be animating after a frame")
+	}
+	m = next.(model)
+
+	next, cmd = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if cmd != nil {
+		t.Error("expected a key pressed during the deal not to start more ticks")
+	}
+	if !next.(model).ticking {
+		t.Error("expected the deal to still be ticking")
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// app hosts whichever screen is active. Screens are ordinary Bubble Tea
+// models that move to another screen by sending a switchScreenMsg, so adding
+// a screen doesn't touch the app itself.
+type app struct {
+	screen tea.Model
+
+	// In accessible mode the terminal size is kept from the screens so
+	// nothing is padded or centred
+	accessible bool
+
+	// Terminal dimensions, passed on to each new screen
+	width  int
+	height int
+}
+
+// switchScreenMsg replaces the active screen
+type switchScreenMsg struct {
+	screen tea.Model
+}
+
+func switchTo(screen tea.Model) tea.Cmd {
+	return func() tea.Msg {
+		return switchScreenMsg{screen}
+	}
+}
+
+func newApp(screen tea.Model, accessible bool) app {
+	return app{screen: screen, accessible: accessible}
+}
+
+func (a app) Init() tea.Cmd {
+	return a.screen.Init()
+}
+
+func (a app) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case switchScreenMsg:
+		a.screen = msg.screen
+		if a.width > 0 && a.height > 0 {
+			a.screen, _ = a.screen.Update(tea.WindowSizeMsg{Width: a.width, Height: a.height})
+		}
+		return a, a.screen.Init()
+
+	case tea.WindowSizeMsg:
+		if a.accessible {
+			return a, nil
+		}
+		a.width = msg.Width
+		a.height = msg.Height
+	}
+
+	var cmd tea.Cmd
+	a.screen, cmd = a.screen.Update(msg)
+	return a, cmd
+}
+
+func (a app) View() string {
+	return a.screen.View()
+}
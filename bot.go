@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+// move is a card to play and how to play it. Unlike a plain mode command it
+// doesn't depend on where the card is in the room.
+type move struct {
+	card   deck.Card
+	action moveAction
+
+	// The relic taken between dungeons, empty to take none
+	relic string
+}
+
+type moveAction string
+
+const (
+	actionPlay   moveAction = "play"
+	actionFists  moveAction = "fists"
+	actionWeapon moveAction = "weapon"
+	actionSkip   moveAction = "skip"
+
+	// Ways to play a joker
+	actionAsPotion moveAction = "potion"
+	actionAsWeapon moveAction = "wield"
+	actionFlee     moveAction = "flee"
+
+	// Taking a relic on offer, or none
+	actionTake moveAction = "take"
+)
+
+// commandFor turns a move into the plain mode command that plays it
+func (m model) commandFor(mv move) string {
+	switch mv.action {
+	case actionSkip:
+		return "skip"
+	case actionTake:
+		if i := slices.Index(m.relicOffers, mv.relic); i >= 0 {
+			return fmt.Sprintf("take %d", i+1)
+		}
+		return "take nothing"
+	}
+	i := slices.Index(m.room, mv.card) + 1
+	switch mv.action {
+	case actionPlay:
+		return fmt.Sprintf("play %d", i)
+	case actionAsPotion:
+		return fmt.Sprintf("play %d potion", i)
+	case actionAsWeapon:
+		return fmt.Sprintf("play %d weapon", i)
+	case actionFlee:
+		return fmt.Sprintf("play %d flee", i)
+	}
+	return fmt.Sprintf("fight %d %s", i, mv.action)
+}
+
+// play applies a move to the game
+func (m *model) play(mv move) error {
+	return m.command(m.commandFor(mv))
+}
+
+// legalMoves lists every move that can be played in the room, or the
+// relics that can be taken when some are on offer
+func (m model) legalMoves() []move {
+	var moves []move
+	if m.viewState == viewStateRelic {
+		for _, name := range m.relicOffers {
+			moves = append(moves, move{action: actionTake, relic: name})
+		}
+		return append(moves, move{action: actionTake})
+	}
+
+	for _, c := range m.room {
+		if isJoker(c) {
+			moves = append(moves, move{card: c, action: actionAsPotion}, move{card: c, action: actionAsWeapon}, move{card: c, action: actionFlee})
+			continue
+		}
+		if !isMonster(c) {
+			moves = append(moves, move{card: c, action: actionPlay})
+			continue
+		}
+		moves = append(moves, move{card: c, action: actionFists})
+		if m.canUseWeapon(c) {
+			moves = append(moves, move{card: c, action: actionWeapon})
+		}
+	}
+	if m.skippable {
+		moves = append(moves, move{action: actionSkip})
+	}
+	return moves
+}
+
+// clone copies the game so it can be played on without touching the original
+func (m model) clone() model {
+	m.dungeon = slices.Clone(m.dungeon)
+	m.room = slices.Clone(m.room)
+	m.discarded = slices.Clone(m.discarded)
+	m.weapon.slain = slices.Clone(m.weapon.slain)
+	m.events = slices.Clone(m.events)
+	m.relics = slices.Clone(m.relics)
+	m.relicOffers = slices.Clone(m.relicOffers)
+	return m
+}
+
+// weaponValue is a rough measure of how useful the equipped weapon still is
+func (m model) weaponValue() int {
+	power := m.weaponPower()
+	if limit, limited := m.weaponLimit(); limited {
+		return power * limit / 14
+	}
+	return power
+}
+
+// botMove picks a move by looking one move ahead, preferring to keep life
+// and a useful weapon. It skips rooms it can't survive, and takes the first
+// relic it's offered.
+func botMove(m model) move {
+	moves := m.legalMoves()
+	if m.viewState == viewStateRelic {
+		return moves[0]
+	}
+
+	if m.skippable {
+		damage := 0
+		for _, c := range m.room {
+			if isMonster(c) {
+				best := m.fistDamage(c)
+				if m.canUseWeapon(c) {
+					best = min(best, m.weaponDamage(c))
+				}
+				damage += best
+			}
+		}
+		if damage >= m.life {
+			return move{action: actionSkip}
+		}
+	}
+
+	best, bestValue := -1, 0
+	for i, mv := range moves {
+		if mv.action == actionSkip {
+			continue
+		}
+		next := m.clone()
+		next.events = nil
+		if err := next.play(mv); err != nil {
+			continue
+		}
+
+		value := 2*next.life + next.weaponValue()
+		if next.viewState == viewStateGameOver && !next.won() {
+			value -= 1000
+		}
+		if best < 0 || value > bestValue {
+			best, bestValue = i, value
+		}
+	}
+	if best < 0 {
+		return moves[0]
+	}
+	return moves[best]
+}
+
+// simResult sums up a batch of games played by the bot
+type simResult struct {
+	games int
+	wins  int
+	total int
+	best  int
+	worst int
+}
+
+// simulate has the bot play games with consecutive seeds from seed
+func simulate(games int, seed int64, s settings) simResult {
+	s.confirmLethal = false
+	s.animations = false
+
+	var r simResult
+	for i := 0; i < games; i++ {
+		m := newGame(seed+int64(i), s.rules(), s)
+		for m.viewState != viewStateGameOver {
+			if err := m.play(botMove(m)); err != nil {
+				break
+			}
+		}
+
+		score := m.score()
+		if r.games == 0 || score > r.best {
+			r.best = score
+		}
+		if r.games == 0 || score < r.worst {
+			r.worst = score
+		}
+		r.games++
+		r.total += score
+		if m.won() {
+			r.wins++
+		}
+	}
+	return r
+}
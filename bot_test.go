@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+func TestLegalMoves(t *testing.T) {
+	m := model{
+		rules: official,
+		life:  20,
+		room: []deck.Card{
+			{Suit: deck.Heart, Rank: 3},
+			{Suit: deck.Spade, Rank: 5},
+		},
+		weapon:    weapon{card: deck.Card{Suit: deck.Diamond, Rank: 4}},
+		skippable: true,
+		viewState: viewStateRoom,
+	}
+
+	moves := m.legalMoves()
+	if len(moves) != 4 {
+		t.Fatalf("expected 4 moves, got %d: %v", len(moves), moves)
+	}
+
+	m.skippable = false
+	if got := len(m.legalMoves()); got != 3 {
+		t.Errorf("expected 3 moves without skipping, got %d", got)
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	r := simulate(5, 1, defaultSettings())
+	if r.games != 5 {
+		t.Errorf("expected 5 games, got %d", r.games)
+	}
+	if r.best < r.worst {
+		t.Errorf("expected best %d to be at least worst %d", r.best, r.worst)
+	}
+
+	// The same seeds play out the same
+	if again := simulate(5, 1, defaultSettings()); again != r {
+		t.Errorf("expected %+v, got %+v", r, again)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	m := model{
+		rules: official,
+		life:  5,
+		room: []deck.Card{
+			{Suit: deck.Spade, Rank: 6},
+			{Suit: deck.Diamond, Rank: 5},
+			{Suit: deck.Heart, Rank: 4},
+		},
+		skippable: false,
+		viewState: viewStateRoom,
+	}
+
+	// Fighting with the weapon and drinking the potion last scores 8 life
+	// plus the potion's 4
+	score, moves, complete := solve(m, 1000)
+	if !complete {
+		t.Errorf("expected the search to complete")
+	}
+	if score != 12 {
+		t.Errorf("expected score to be 12, got %d (%v)", score, moves)
+	}
+	if len(moves) != 3 {
+		t.Errorf("expected 3 moves, got %v", moves)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	s := defaultSettings()
+	s.confirmLethal = false
+	m := newGame(7, s.rules(), s)
+	for m.viewState != viewStateGameOver {
+		if err := m.play(botMove(m)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), replayFile)
+	if err := saveReplay(path, newReplay(m)); err != nil {
+		t.Fatal(err)
+	}
+	r, err := loadReplay(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := runReplay(&out, r, s); err != nil {
+		t.Fatal(err)
+	}
+	if want := m.gameOverSummary(); !strings.Contains(out.String(), want) {
+		t.Errorf("expected replay to end with %q, got %q", want, out.String())
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+// campaign is the state of an endless run down through ever harder
+// dungeons. A depth of 0 means the game isn't a campaign.
+type campaign struct {
+	depth       int
+	carryWeapon bool
+}
+
+// newCampaign starts a campaign at the first dungeon, which is dealt the
+// same as a normal game with the seed
+func newCampaign(seed int64, r Rules, s settings) model {
+	m := newGame(seed, r, s)
+	m.campaign = campaign{depth: 1, carryWeapon: s.carryWeapon}
+	return m
+}
+
+func (m model) inCampaign() bool {
+	return m.campaign.depth > 0
+}
+
+// descend moves a campaign on to the next dungeon, keeping life and, if the
+// campaign allows it, the weapon
+func (m *model) descend() {
+	m.campaign.depth++
+	m.log(eventDescend, deck.Card{}, m.life)
+
+	m.dungeon = campaignDungeon(m.seed, m.campaign.depth, m.rules)
+	m.room = deck.Pile{}
+	m.discarded = deck.Pile{}
+	if !m.campaign.carryWeapon {
+		m.weapon = weapon{slain: deck.Pile{}}
+	}
+	m.viewState = viewStateRoom
+	m.selection = 0
+	m.skippable = true
+	m.drawToRoom(m.rules.RoomSize)
+	m.roomDrawn()
+	m.offerRelics()
+}
+
+// campaignDungeon deals the dungeon at a depth of a campaign. Each level down
+// loses its strongest potion, and from the third on two more monsters from a
+// second deck join for every level.
+func campaignDungeon(seed int64, depth int, r Rules) []deck.Card {
+	d := newDungeon(seed+int64(depth-1), r)
+	if depth <= 1 {
+		return d
+	}
+
+	for range depth - 1 {
+		strongest := -1
+		for i, c := range d {
+			if c.Suit == deck.Heart && (strongest < 0 || c.Rank > d[strongest].Rank) {
+				strongest = i
+			}
+		}
+		if strongest < 0 {
+			break
+		}
+		d = slices.Delete(d, strongest, strongest+1)
+	}
+
+	if extra := 2 * (depth - 2); extra > 0 {
+		monsters := deck.New(
+			deck.Deck(2),
+			deck.Keep(isMonster),
+			deck.Keep(r.composition().has),
+			deck.SeededShuffle(seed+int64(depth)),
+		)
+		d = append(d, monsters[:min(extra, len(monsters))]...)
+	}
+	return r.Shuffle.shuffle(seed + int64(depth))(d)
+}
+
+const leaderboardFile = "campaign.json"
+
+// leaderboardSize is how many of the best campaigns are kept
+const leaderboardSize = 10
+
+// campaignEntry is a finished campaign on the leaderboard. Score is the
+// score of the last dungeon, and Total the campaign's score, the depth
+// reached plus that.
+type campaignEntry struct {
+	Depth int
+	Score int
+	Total int
+	Seed  int64
+	Rules string
+	Date  string
+}
+
+func newCampaignEntry(m model, now time.Time) campaignEntry {
+	return campaignEntry{
+		Depth: m.campaign.depth,
+		Score: m.score(),
+		Total: m.campaignScore(),
+		Seed:  m.seed,
+		Rules: m.rules.String(),
+		Date:  now.Format(time.DateOnly),
+	}
+}
+
+// campaignScore is the depth reached plus the final score
+func (m model) campaignScore() int {
+	return m.campaign.depth + m.score()
+}
+
+// better ranks campaigns by their total, then by how deep they got
+func (e campaignEntry) better(o campaignEntry) bool {
+	if e.Total != o.Total {
+		return e.Total > o.Total
+	}
+	return e.Depth > o.Depth
+}
+
+func loadLeaderboard(dir string) ([]campaignEntry, error) {
+	data, err := os.ReadFile(filepath.Join(dir, leaderboardFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []campaignEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	// Campaigns recorded before the total was kept
+	for i, e := range entries {
+		entries[i].Total = e.Depth + e.Score
+	}
+	return entries, nil
+}
+
+// recordCampaign adds a finished campaign to the leaderboard kept in dir if
+// it's among the best
+func recordCampaign(dir string, e campaignEntry) error {
+	entries, err := loadLeaderboard(dir)
+	if err != nil {
+		return err
+	}
+
+	entries = append(entries, e)
+	slices.SortStableFunc(entries, func(a, b campaignEntry) int {
+		switch {
+		case a.better(b):
+			return -1
+		case b.better(a):
+			return 1
+		}
+		return 0
+	})
+	if len(entries) > leaderboardSize {
+		entries = entries[:leaderboardSize]
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, leaderboardFile), data, 0o644)
+}
+
+// leaderboardView lists the best campaigns one per line
+func leaderboardView(entries []campaignEntry) string {
+	lines := []string{"Campaign leaderboard"}
+	for i, e := range entries {
+		lines = append(lines, fmt.Sprintf("%2d. score %d, depth %d + %d (%s, seed %d, %s)", i+1, e.Total, e.Depth, e.Score, e.Rules, e.Seed, e.Date))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+func TestCampaignDungeon(t *testing.T) {
+	tests := []struct {
+		depth           int
+		expectedLen     int
+		expectedPotions int
+	}{
+		{1, 44, 9},
+		{2, 43, 8},
+		{3, 44, 7},
+		{5, 46, 5},
+	}
+
+	for _, test := range tests {
+		d := campaignDungeon(3, test.depth, official)
+		assertExpectedDungeonLength(t, len(d), test.expectedLen)
+
+		potions, strongest := 0, deck.Rank(0)
+		for _, c := range d {
+			if c.Suit == deck.Heart {
+				potions++
+				strongest = max(strongest, c.Rank)
+			}
+		}
+		if potions != test.expectedPotions {
+			t.Errorf("expected %d potions at depth %d, got %d", test.expectedPotions, test.depth, potions)
+		}
+		if want := deck.Rank(test.expectedPotions + 1); strongest != want {
+			t.Errorf("expected the strongest potion at depth %d to be %d, got %d", test.depth, want, strongest)
+		}
+	}
+
+	if !reflect.DeepEqual(campaignDungeon(3, 1, official), newDungeon(3, official)) {
+		t.Errorf("expected the first dungeon of a campaign to be dealt like a normal game")
+	}
+}
+
+// The extra monsters deeper down come from the cards of the rules' deck
+func TestCampaignDungeonCustomDeck(t *testing.T) {
+	d, err := parseDeck(strings.NewReader(`
+spades = "2-5"
+clubs = "none"
+`), "low")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := settings{variant: "official", deck: d}.rules()
+
+	monsters := 0
+	for _, c := range campaignDungeon(3, 5, r) {
+		if !r.composition().has(c) {
+			t.Errorf("expected only cards of the low deck, got the %s", c)
+		}
+		if isMonster(c) {
+			monsters++
+		}
+	}
+	if monsters != 4+6 {
+		t.Errorf("expected the 4 spades and 6 more from a second deck, got %d monsters", monsters)
+	}
+}
+
+func TestDescend(t *testing.T) {
+	for _, carry := range []bool{false, true} {
+		m := newCampaign(3, official, settings{carryWeapon: carry})
+		m.life = 7
+		m.weapon = weapon{card: deck.Card{Suit: deck.Diamond, Rank: 5}, slain: []deck.Card{}}
+		m.dungeon = []deck.Card{}
+		m.room = []deck.Card{{Suit: deck.Heart, Rank: 2}}
+		m.selection = 0
+
+		m.playRoom()
+
+		if m.viewState != viewStateRelic {
+			t.Errorf("expected relics to be offered, got view %s", m.viewState)
+		}
+		if m.campaign.depth != 2 {
+			t.Errorf("expected depth to be 2, got %d", m.campaign.depth)
+		}
+		assertExpectedLife(t, m.life, 9)
+		if len(m.room) != 4 || len(m.dungeon) != 43-4 {
+			t.Errorf("expected a fresh dungeon of 43 cards, got %d in the room and %d left", len(m.room), len(m.dungeon))
+		}
+		if m.weapon.equipped() != carry {
+			t.Errorf("expected weapon kept to be %t, got %t", carry, m.weapon.equipped())
+		}
+	}
+}
+
+func TestRecordCampaign(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	entries := []campaignEntry{
+		{Depth: 2, Score: 5},
+		{Depth: 4, Score: -20},
+		{Depth: 2, Score: 9},
+	}
+	for i := range leaderboardSize {
+		entries = append(entries, campaignEntry{Depth: 1, Score: -i})
+	}
+	for _, e := range entries {
+		if err := recordCampaign(dir, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := newCampaign(1, official, settings{})
+	m.campaign.depth = 3
+	m.life = 15
+	m.dungeon, m.room = nil, nil
+	if err := recordCampaign(dir, newCampaignEntry(m, now)); err != nil {
+		t.Fatal(err)
+	}
+
+	leaderboard, err := loadLeaderboard(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(leaderboard) != leaderboardSize {
+		t.Fatalf("expected %d entries, got %d", leaderboardSize, len(leaderboard))
+	}
+	var totals []int
+	for _, e := range leaderboard[:5] {
+		totals = append(totals, e.Total)
+	}
+	if !reflect.DeepEqual(totals, []int{18, 11, 7, 1, 0}) {
+		t.Errorf("expected the best depth plus score first, got totals %v", totals)
+	}
+	if leaderboard[3].Depth != 1 {
+		t.Errorf("expected a deeper campaign with a worse total to rank lower, got %+v", leaderboard[:5])
+	}
+	if e := leaderboard[0]; e.Date != "2026-10-19" || e.Rules != "official" || e.Depth != 3 || e.Score != 15 {
+		t.Errorf("expected the date, rules, depth and score to be recorded, got %+v", e)
+	}
+}
+
+func TestSaveCampaign(t *testing.T) {
+	dir := t.TempDir()
+	s := settings{dataDir: dir, carryWeapon: true}
+	m := newCampaign(7, official, s)
+	m.campaign.depth = 3
+
+	if err := saveGame(dir, m); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := loadGame(dir, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.campaign != m.campaign {
+		t.Errorf("expected campaign to be %+v, got %+v", m.campaign, loaded.campaign)
+	}
+}
@@ -0,0 +1,341 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const usage = `Scoundrel, the single player rogue-like card game.
+
+Usage:
+  scoundrel [command] [flags]
+
+Commands:
+  play     play in the terminal (the default)
+  sim      have a bot play many games and report how it did
+  solve    search for the best way to play a seed
+  replay   play back a finished game
+  stats    print your statistics
+  config   print the configuration in effect
+
+Run "scoundrel <command> --help" for the flags of each command.
+
+Defaults are read from $XDG_CONFIG_HOME/scoundrel/config.toml, and flags
+override them.
+`
+
+// seedFlag is a seed that remembers whether it was given at all
+type seedFlag struct {
+	value int64
+	set   bool
+}
+
+func (f *seedFlag) String() string {
+	if !f.set {
+		return ""
+	}
+	return strconv.FormatInt(f.value, 10)
+}
+
+func (f *seedFlag) Set(s string) error {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("seed must be a whole number")
+	}
+	f.value = v
+	f.set = true
+	return nil
+}
+
+// or returns the seed if one was given, otherwise a fresh one
+func (f *seedFlag) or(seed int64) int64 {
+	if f.set {
+		return f.value
+	}
+	return seed
+}
+
+// command is a subcommand of the CLI
+type command struct {
+	name string
+	run  func(args []string, out io.Writer, s settings) error
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"play", runPlayCommand},
+		{"sim", runSimCommand},
+		{"solve", runSolveCommand},
+		{"replay", runReplayCommand},
+		{"stats", runStatsCommand},
+		{"config", runConfigCommand},
+	}
+}
+
+// run runs the CLI with the arguments after the program name
+func run(args []string, out io.Writer, errOut io.Writer) int {
+	name := "play"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	} else if len(args) > 0 && (args[0] == "-h" || args[0] == "-help" || args[0] == "--help") {
+		fmt.Fprint(out, usage)
+		return 0
+	}
+	if name == "help" {
+		fmt.Fprint(out, usage)
+		return 0
+	}
+
+	s := defaultSettings()
+	if err := loadConfig(configPath(), &s); err != nil {
+		fmt.Fprintf(errOut, "Couldn't read the config: %v\n", err)
+		return 1
+	}
+
+	for _, c := range commands {
+		if c.name != name {
+			continue
+		}
+		err := c.run(args, out, s)
+		if err == flag.ErrHelp {
+			return 0
+		}
+		if err != nil {
+			fmt.Fprintf(errOut, "Alas, there's been an error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintf(errOut, "Unknown command %q.\n\n%s", name, usage)
+	return 2
+}
+
+// flagSet creates the flags for a command, including those for the rules
+// every command plays by
+func flagSet(name string, help string, out io.Writer, s *settings) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(out)
+	fs.Usage = func() {
+		fmt.Fprintf(out, "%s\n\nUsage:\n  scoundrel %s [flags]\n\nFlags:\n", help, name)
+		fs.PrintDefaults()
+	}
+
+	fs.IntVar(&s.startLife, "life", s.startLife, "starting life, overriding the variant's, 0 to keep the variant's")
+	fs.StringVar(&s.variant, "variant", s.variant, "rules variant, one of "+strings.Join(variantNames(), ", "))
+	fs.StringVar(&s.degradation, "degradation", s.degradation, "whether a used weapon can hit a monster as strong as the last it slew, lenient, or only weaker ones, strict (default from the variant)")
+	fs.Func("shuffle", "how the dungeon is shuffled, uniform, or by hand like 3 riffles or 10 overhands", func(value string) error {
+		shuffle, err := parseShuffle(value)
+		if err != nil {
+			return err
+		}
+		s.shuffle = shuffle
+		return nil
+	})
+	fs.Func("deck", "deck definition file of the cards to deal the dungeon from", func(path string) error {
+		d, err := loadDeck(path)
+		if err != nil {
+			return err
+		}
+		s.deck = d
+		return nil
+	})
+	return fs
+}
+
+// checkSettings rejects settings naming something that doesn't exist
+func checkSettings(s settings) error {
+	if _, ok := presets[s.variant]; !ok {
+		return fmt.Errorf("unknown variant %q, choose one of %s", s.variant, strings.Join(variantNames(), ", "))
+	}
+	if _, ok := themes[s.theme]; !ok {
+		return fmt.Errorf("unknown theme %q, choose one of %s", s.theme, strings.Join(themeNames(), ", "))
+	}
+	if s.degradation != "" && s.degradation != strictDegradation && s.degradation != lenientDegradation {
+		return fmt.Errorf("unknown weapon degradation %q, choose strict or lenient", s.degradation)
+	}
+	if s.startLife < 0 {
+		return fmt.Errorf("starting life can't be negative")
+	}
+	if r := s.rules(); len(newDungeon(0, r)) < 2*r.RoomSize {
+		return fmt.Errorf("the %s deck has too few cards for a game, it needs at least %d", r.Deck.Name, 2*r.RoomSize)
+	}
+	return nil
+}
+
+func runPlayCommand(args []string, out io.Writer, s settings) error {
+	var seed seedFlag
+	fs := flagSet("play", "Play in the terminal.", out, &s)
+	fs.Var(&seed, "seed", "seed of the deal")
+	noAltScreen := fs.Bool("no-alt-screen", false, "draw inline instead of taking over the terminal")
+	plain := fs.Bool("plain", false, "play line by line on stdin and stdout without the terminal UI")
+	campaign := fs.Bool("campaign", false, "play a campaign through ever deeper dungeons")
+	fs.BoolVar(&s.carryWeapon, "carry-weapon", s.carryWeapon, "keep the weapon when a campaign goes down a level")
+	fs.BoolVar(&s.ascii, "ascii", s.ascii, "draw with plain ASCII instead of emoji and box drawing")
+	fs.StringVar(&s.theme, "theme", s.theme, "colour theme, one of "+strings.Join(themeNames(), ", "))
+	fs.BoolVar(&s.accessible, "accessible", s.accessible, "screen-reader-friendly mode without the alternate screen")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := checkSettings(s); err != nil {
+		return err
+	}
+
+	start := newGame
+	if *campaign {
+		start = newCampaign
+	}
+	if *plain {
+		return runPlain(stdin, out, start(seed.or(newSeed()), s.rules(), s))
+	}
+
+	// A seed, campaign or accessible mode goes straight into the game,
+	// otherwise start at the menu
+	var screen tea.Model = newMenu(s)
+	if seed.set || *campaign || s.accessible {
+		screen = start(seed.or(newSeed()), s.rules(), s)
+	}
+
+	var opts []tea.ProgramOption
+	if !s.accessible {
+		opts = append(opts, tea.WithMouseAllMotion())
+		if !*noAltScreen {
+			opts = append(opts, tea.WithAltScreen())
+		}
+	}
+
+	_, err := tea.NewProgram(newApp(screen, s.accessible), opts...).Run()
+	return err
+}
+
+func runSimCommand(args []string, out io.Writer, s settings) error {
+	var seed seedFlag
+	fs := flagSet("sim", "Have a bot play games with consecutive seeds and report how it did.", out, &s)
+	fs.Var(&seed, "seed", "seed of the deal")
+	games := fs.Int("games", 1000, "number of games to play")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := checkSettings(s); err != nil {
+		return err
+	}
+	if *games < 1 {
+		return fmt.Errorf("play at least one game")
+	}
+
+	first := seed.or(newSeed())
+	r := simulate(*games, first, s)
+	fmt.Fprintf(out, "Games:   %d (seeds %d to %d)\n", r.games, first, first+int64(r.games)-1)
+	fmt.Fprintf(out, "Won:     %d (%.1f%%)\n", r.wins, 100*float64(r.wins)/float64(r.games))
+	fmt.Fprintf(out, "Average: %.1f\n", float64(r.total)/float64(r.games))
+	fmt.Fprintf(out, "Best:    %d\n", r.best)
+	fmt.Fprintf(out, "Worst:   %d\n", r.worst)
+	return nil
+}
+
+func runSolveCommand(args []string, out io.Writer, s settings) error {
+	var seed seedFlag
+	fs := flagSet("solve", "Search for the best way to play a seed.", out, &s)
+	fs.Var(&seed, "seed", "seed of the deal")
+	position := fs.String("position", "", "position to solve from, in the notation the plain mode's position command shows")
+	budget := fs.Int("budget", 2000000, "most positions to search before letting the bot finish the rest")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := checkSettings(s); err != nil {
+		return err
+	}
+
+	var m model
+	switch {
+	case *position != "":
+		p, err := ParsePosition(*position)
+		if err != nil {
+			return err
+		}
+		if err := p.validate(s.rules()); err != nil {
+			return err
+		}
+		m = newPosition(p, s.rules(), s)
+	case seed.set:
+		m = newGame(seed.value, s.rules(), s)
+	default:
+		return fmt.Errorf("give the seed to solve with --seed, or a position with --position")
+	}
+
+	score, moves, complete := solve(m, *budget)
+	for i, move := range moves {
+		fmt.Fprintf(out, "%3d. %s\n", i+1, move)
+	}
+	if complete {
+		fmt.Fprintf(out, "Best score: %d\n", score)
+	} else {
+		fmt.Fprintf(out, "Best score found: %d (search stopped after %d positions)\n", score, *budget)
+	}
+	return nil
+}
+
+func runReplayCommand(args []string, out io.Writer, s settings) error {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	fs.SetOutput(out)
+	fs.Usage = func() {
+		fmt.Fprintf(out, "Play back a finished game, by default the last one played.\n\nUsage:\n  scoundrel replay [file]\n")
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.dataDir, replayFile)
+	if fs.NArg() > 0 {
+		path = fs.Arg(0)
+	}
+	r, err := loadReplay(path)
+	if err != nil {
+		return err
+	}
+	return runReplay(out, r, s)
+}
+
+func runStatsCommand(args []string, out io.Writer, s settings) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	fs.SetOutput(out)
+	fs.Usage = func() {
+		fmt.Fprintf(out, "Print your statistics.\n\nUsage:\n  scoundrel stats\n")
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	st, err := loadStats(s.dataDir)
+	if err != nil {
+		return err
+	}
+	leaderboard, err := loadLeaderboard(s.dataDir)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, statsModel{settings: s, stats: st, leaderboard: leaderboard}.summary())
+	return nil
+}
+
+func runConfigCommand(args []string, out io.Writer, s settings) error {
+	fs := flagSet("config", "Print the configuration in effect, with any flags applied.", out, &s)
+	fs.StringVar(&s.theme, "theme", s.theme, "colour theme, one of "+strings.Join(themeNames(), ", "))
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := checkSettings(s); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "# %s\n%s", configPath(), formatConfig(s))
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunCommands(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	tests := []struct {
+		name string
+		args []string
+		code int
+		out  string
+		err  string
+	}{
+		{"help", []string{"--help"}, 0, "Commands:", ""},
+		{"help command", []string{"help"}, 0, "Commands:", ""},
+		{"command help", []string{"sim", "--help"}, 0, "-games", ""},
+		{"life help", []string{"sim", "--help"}, 0, "0 to keep the variant's", ""},
+		{"unknown command", []string{"bogus"}, 2, "", `Unknown command "bogus"`},
+		{"unknown variant", []string{"sim", "--variant", "bogus"}, 1, "", `unknown variant "bogus"`},
+		{"bad seed", []string{"sim", "--seed", "abc"}, 1, "", "seed must be a whole number"},
+		{"solve without seed", []string{"solve"}, 1, "", "give the seed"},
+		{"solve impossible position", []string{"solve", "--position", "9S 4H 99 - s"}, 1, "", "life must be from 1 to 20"},
+		{"sim", []string{"sim", "--games", "3", "--seed", "1"}, 0, "Games:   3 (seeds 1 to 3)", ""},
+		{"config", []string{"config", "--theme", "mono"}, 0, `theme = "mono"`, ""},
+		{"replay missing file", []string{"replay", filepath.Join(t.TempDir(), "missing.json")}, 1, "", "no such file"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+			code := run(test.args, &out, &errOut)
+
+			if code != test.code {
+				t.Errorf("expected exit code to be %d, got %d (%s)", test.code, code, errOut.String())
+			}
+			if !strings.Contains(out.String(), test.out) {
+				t.Errorf("expected output to contain %q, got %q", test.out, out.String())
+			}
+			if !strings.Contains(errOut.String(), test.err) {
+				t.Errorf("expected errors to contain %q, got %q", test.err, errOut.String())
+			}
+		})
+	}
+}
+
+func TestStartingLife(t *testing.T) {
+	s := settings{startLife: 30}
+	m := newGame(1, s.rules(), s)
+	if m.life != 30 {
+		t.Errorf("expected life to be 30, got %d", m.life)
+	}
+	if m.rules.MaxLife != 30 {
+		t.Errorf("expected max life to be 30, got %d", m.rules.MaxLife)
+	}
+	if len(m.room) != 4 {
+		t.Errorf("expected room to have 4 cards, got %d", len(m.room))
+	}
+}
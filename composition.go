@@ -0,0 +1,247 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+// Composition is which cards a dungeon is dealt from. The zero value is the
+// standard Scoundrel dungeon, a deck without the red faces and red Aces.
+type Composition struct {
+	Name string
+
+	// The ranks of each suit in the dungeon, a bit set by 1 << rank
+	Ranks [4]uint16
+
+	// How many of each card there are
+	Copies int
+}
+
+// standardComposition is the dungeon of the official rules, a deck without
+// the red faces and red Aces
+var standardComposition = compositionOf("standard", deck.Remove(
+	deck.And(deck.IsRed, deck.Or(deck.IsFace, deck.ByRank(deck.Ace))),
+))
+
+// compositionOf is the composition of a single deck with the option applied
+func compositionOf(name string, opt func([]deck.Card) []deck.Card) Composition {
+	c := Composition{Name: name, Copies: 1}
+	for _, card := range deck.New(opt) {
+		c.Ranks[card.Suit] |= 1 << card.Rank
+	}
+	return c
+}
+
+// composition is the cards the dungeon is dealt from
+func (r Rules) composition() Composition {
+	if r.Deck == (Composition{}) {
+		return standardComposition
+	}
+	return r.Deck
+}
+
+// has reports whether a card is in the dungeon
+func (c Composition) has(card deck.Card) bool {
+	return card.Suit < deck.Joker && c.Ranks[card.Suit]&(1<<card.Rank) != 0
+}
+
+// cards deals the cards of the composition in order, before any shuffle
+func (c Composition) cards() []deck.Card {
+	return deck.New(
+		deck.Keep(c.has),
+		deck.Deck(c.Copies),
+	)
+}
+
+// code identifies the cards of a composition whatever it's named
+func (c Composition) code() string {
+	h := fnv.New32a()
+	binary.Write(h, binary.LittleEndian, c.Ranks)
+	binary.Write(h, binary.LittleEndian, int32(c.Copies))
+	return fmt.Sprintf("%08x", h.Sum32())
+}
+
+// validate rejects a composition that can't make a game
+func (c Composition) validate() error {
+	if c.Copies < 1 || c.Copies > 4 {
+		return fmt.Errorf("copies must be from 1 to 4, got %d", c.Copies)
+	}
+	if c.Ranks[deck.Spade] == 0 && c.Ranks[deck.Club] == 0 {
+		return fmt.Errorf("the dungeon needs monsters, spades or clubs")
+	}
+	return nil
+}
+
+// deckFile is a composition read from a file along with the jokers it asks
+// for, -1 if it doesn't say
+type deckFile struct {
+	composition Composition
+	jokers      int
+	jokerValue  int
+}
+
+// loadDeck reads a deck definition file. It's named after the file unless it
+// gives a name.
+func loadDeck(path string) (deckFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return deckFile{}, err
+	}
+	defer f.Close()
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	d, err := parseDeck(f, name)
+	if err != nil {
+		return deckFile{}, fmt.Errorf("%s:%w", path, err)
+	}
+	return d, nil
+}
+
+// parseDeck reads a deck definition in the same TOML subset as the config.
+// Suits not mentioned keep their standard ranks, so
+//
+//	diamonds = "2-7"
+//
+// plays the standard dungeon without diamonds above 7.
+func parseDeck(r io.Reader, name string) (deckFile, error) {
+	d := deckFile{composition: standardComposition, jokers: -1}
+	d.composition.Name = name
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return deckFile{}, fmt.Errorf("%d: expected name = value", n)
+		}
+		if err := d.set(strings.TrimSpace(key), strings.TrimSpace(value)); err != nil {
+			return deckFile{}, fmt.Errorf("%d: %v", n, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return deckFile{}, err
+	}
+
+	if err := d.composition.validate(); err != nil {
+		return deckFile{}, err
+	}
+	if d.jokers > 4 {
+		return deckFile{}, fmt.Errorf("jokers must be from 0 to 4, got %d", d.jokers)
+	}
+	if d.jokers > 0 && d.jokerValue == 0 {
+		d.jokerValue = presets["jokers"].JokerValue
+	}
+	return d, nil
+}
+
+var suitKeys = map[string]deck.Suit{
+	"spades":   deck.Spade,
+	"diamonds": deck.Diamond,
+	"clubs":    deck.Club,
+	"hearts":   deck.Heart,
+}
+
+// set applies a single line of a deck definition
+func (d *deckFile) set(key string, value string) error {
+	if suit, ok := suitKeys[key]; ok {
+		var ranks string
+		if err := parseString(value, &ranks); err != nil {
+			return err
+		}
+		set, err := parseRanks(ranks)
+		if err != nil {
+			return err
+		}
+		d.composition.Ranks[suit] = set
+		return nil
+	}
+
+	switch key {
+	case "name":
+		return parseString(value, &d.composition.Name)
+	case "copies":
+		return parseInt(value, &d.composition.Copies)
+	case "jokers":
+		if err := parseInt(value, &d.jokers); err != nil {
+			return err
+		}
+		if d.jokers < 0 {
+			return fmt.Errorf("jokers can't be negative")
+		}
+		return nil
+	case "joker_value":
+		if err := parseInt(value, &d.jokerValue); err != nil {
+			return err
+		}
+		if d.jokerValue < 1 || d.jokerValue > 14 {
+			return fmt.Errorf("joker_value must be from 1 to 14, got %d", d.jokerValue)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown setting %q", key)
+	}
+}
+
+// rankOrder is the ranks from weakest to strongest, as ranges are written
+var rankOrder = []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}
+
+// parseRanks reads a list of ranks and ranges like "2-7, J, A". Ranges run
+// from weaker to stronger with the Ace high. "none" or nothing at all leaves
+// the suit out.
+func parseRanks(s string) (uint16, error) {
+	var set uint16
+	if s = strings.TrimSpace(s); s == "" || strings.EqualFold(s, "none") {
+		return 0, nil
+	}
+
+	for _, item := range strings.Split(s, ",") {
+		low, high, isRange := strings.Cut(strings.TrimSpace(item), "-")
+		from, err := rankIndex(low)
+		if err != nil {
+			return 0, err
+		}
+		to := from
+		if isRange {
+			if to, err = rankIndex(high); err != nil {
+				return 0, err
+			}
+			if to < from {
+				return 0, fmt.Errorf("range %s runs backwards, write it weakest first", strings.TrimSpace(item))
+			}
+		}
+		for i := from; i <= to; i++ {
+			set |= 1 << rankOf(i)
+		}
+	}
+	return set, nil
+}
+
+func rankIndex(s string) (int, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	for i, r := range rankOrder {
+		if r == s {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown rank %q, use 2 to 10, J, Q, K or A", s)
+}
+
+// rankOf is the deck rank at a position of rankOrder
+func rankOf(i int) deck.Rank {
+	if rankOrder[i] == "A" {
+		return deck.Ace
+	}
+	return deck.Two + deck.Rank(i)
+}
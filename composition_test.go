@@ -0,0 +1,120 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+func TestStandardComposition(t *testing.T) {
+	d := newDungeon(1, official)
+	assertExpectedDungeonLength(t, len(d), 44)
+
+	for _, c := range d {
+		if (c.Suit == deck.Heart || c.Suit == deck.Diamond) && (c.Rank == deck.Ace || c.Rank > deck.Ten) {
+			t.Errorf("expected no red faces or Aces, got the %s", c)
+		}
+	}
+}
+
+func TestParseRanks(t *testing.T) {
+	tests := []struct {
+		ranks    string
+		expected []deck.Rank
+	}{
+		{"2-4", []deck.Rank{deck.Two, deck.Three, deck.Four}},
+		{"J, A", []deck.Rank{deck.Jack, deck.Ace}},
+		{"k-a", []deck.Rank{deck.King, deck.Ace}},
+		{"none", nil},
+	}
+
+	for _, test := range tests {
+		set, err := parseRanks(test.ranks)
+		if err != nil {
+			t.Fatalf("expected %q to parse, got %v", test.ranks, err)
+		}
+		var expected uint16
+		for _, r := range test.expected {
+			expected |= 1 << r
+		}
+		if set != expected {
+			t.Errorf("expected %q to be %b, got %b", test.ranks, expected, set)
+		}
+	}
+}
+
+func TestParseDeck(t *testing.T) {
+	d, err := parseDeck(strings.NewReader(`
+# no diamonds above 7
+diamonds = "2-7"
+jokers = 1
+`), "low")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := settings{variant: "official", deck: d}
+	r := s.rules()
+	if r.Jokers != 1 || r.JokerValue != 6 {
+		t.Errorf("expected 1 joker of 6, got %d of %d", r.Jokers, r.JokerValue)
+	}
+	if r.String() != "official, 1 joker of 6, low deck" {
+		t.Errorf("expected rules to be %q, got %q", "official, 1 joker of 6, low deck", r.String())
+	}
+
+	dungeon := newDungeon(1, r)
+	assertExpectedDungeonLength(t, len(dungeon), 44-3+1)
+	for _, c := range dungeon {
+		if c.Suit == deck.Diamond && c.Rank > deck.Seven {
+			t.Errorf("expected no diamonds above 7, got the %s", c)
+		}
+	}
+}
+
+func TestParseDeckErrors(t *testing.T) {
+	tests := []struct {
+		deck string
+		err  string
+	}{
+		{`spades = "none"` + "\n" + `clubs = ""`, "needs monsters"},
+		{`copies = 5`, "copies must be from 1 to 4"},
+		{`hearts = "7-2"`, "runs backwards"},
+		{`hearts = "2-11"`, `1: unknown rank "11"`},
+		{`jokers = 9`, "jokers must be from 0 to 4"},
+		{`rubies = "2-10"`, `unknown setting "rubies"`},
+	}
+
+	for _, test := range tests {
+		_, err := parseDeck(strings.NewReader(test.deck), "test")
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("expected error containing %q, got %v", test.err, err)
+		}
+	}
+}
+
+func TestDeckFlag(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dir := t.TempDir()
+
+	doubled := filepath.Join(dir, "doubled.toml")
+	if err := os.WriteFile(doubled, []byte("copies = 2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tiny := filepath.Join(dir, "tiny.toml")
+	if err := os.WriteFile(tiny, []byte("spades = \"2\"\nclubs = \"none\"\ndiamonds = \"none\"\nhearts = \"none\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out, errOut strings.Builder
+	if code := run([]string{"sim", "--games", "2", "--seed", "1", "--deck", doubled}, &out, &errOut); code != 0 {
+		t.Errorf("expected a doubled deck to play, got %s", errOut.String())
+	}
+
+	errOut.Reset()
+	if code := run([]string{"sim", "--deck", tiny}, &out, &errOut); code != 1 || !strings.Contains(errOut.String(), "too few cards") {
+		t.Errorf("expected a tiny deck to be rejected, got %d: %s", code, errOut.String())
+	}
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const configFile = "config.toml"
+
+// configPath is where the config file is read from, following the XDG base
+// directory spec
+func configPath() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "scoundrel", configFile)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return configFile
+	}
+	return filepath.Join(home, ".config", "scoundrel", configFile)
+}
+
+// loadConfig applies the config file at path over the settings. A missing
+// file leaves them as they are.
+func loadConfig(path string, s *settings) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := parseConfig(f, s); err != nil {
+		return fmt.Errorf("%s:%w", path, err)
+	}
+	return nil
+}
+
+// parseConfig reads the small subset of TOML the config needs: top level
+// settings and a [keys] table, with strings, booleans, integers and arrays
+// of strings as values
+func parseConfig(r io.Reader, s *settings) error {
+	scanner := bufio.NewScanner(r)
+	table := ""
+
+	// The lines actions were bound on, so a clash can be reported on the
+	// later of them once all the keys are read. Bindings can move a key
+	// from one action to another over several lines.
+	keyLines := map[string]int{}
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			table = strings.TrimSpace(line[1 : len(line)-1])
+			if table != "keys" {
+				return fmt.Errorf("%d: unknown table %q", n, table)
+			}
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf("%d: expected name = value", n)
+		}
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+
+		var err error
+		if table == "keys" {
+			err = s.setKeys(name, value)
+			keyLines[name] = n
+		} else {
+			err = s.set(name, value)
+		}
+		if err != nil {
+			return fmt.Errorf("%d: %v", n, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if key, first, second, found := s.keys.clash(); found {
+		return fmt.Errorf("%d: %q is bound to both %s and %s", max(keyLines[first], keyLines[second]), key, first, second)
+	}
+	return nil
+}
+
+// set applies a single top level config setting
+func (s *settings) set(name string, value string) error {
+	switch name {
+	case "variant":
+		return parseString(value, &s.variant)
+	case "theme":
+		return parseString(value, &s.theme)
+	case "data_dir":
+		if err := parseString(value, &s.dataDir); err != nil {
+			return err
+		}
+		if rest, ok := strings.CutPrefix(s.dataDir, "~/"); ok {
+			if home, err := os.UserHomeDir(); err == nil {
+				s.dataDir = filepath.Join(home, rest)
+			}
+		}
+		return nil
+	case "life":
+		return parseInt(value, &s.startLife)
+	case "degradation":
+		return parseString(value, &s.degradation)
+	case "shuffle":
+		var shuffle string
+		if err := parseString(value, &shuffle); err != nil {
+			return err
+		}
+		parsed, err := parseShuffle(shuffle)
+		if err != nil {
+			return err
+		}
+		s.shuffle = parsed
+		return nil
+	case "animations":
+		return parseBool(value, &s.animations)
+	case "carry_weapon":
+		return parseBool(value, &s.carryWeapon)
+	case "confirm_lethal":
+		return parseBool(value, &s.confirmLethal)
+	case "show_counter":
+		return parseBool(value, &s.showCounter)
+	case "ascii":
+		return parseBool(value, &s.ascii)
+	case "accessible":
+		return parseBool(value, &s.accessible)
+	default:
+		return fmt.Errorf("unknown setting %q", name)
+	}
+}
+
+// setKeys binds an action to the keys in the config
+func (s *settings) setKeys(action string, value string) error {
+	if _, ok := defaultKeymap()[action]; !ok {
+		return fmt.Errorf("unknown action %q", action)
+	}
+
+	if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+		return fmt.Errorf("keys for %s should be a list like [\"up\", \"k\"]", action)
+	}
+	var keys []string
+	for _, item := range strings.Split(value[1:len(value)-1], ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		var key string
+		if err := parseString(item, &key); err != nil {
+			return err
+		}
+		keys = append(keys, key)
+	}
+
+	s.keys[action] = keys
+	return nil
+}
+
+func parseString(value string, v *string) error {
+	s, err := strconv.Unquote(value)
+	if err != nil || !strings.HasPrefix(value, `"`) {
+		return fmt.Errorf("expected a quoted string, got %s", value)
+	}
+	*v = s
+	return nil
+}
+
+func parseInt(value string, v *int) error {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("expected a whole number, got %s", value)
+	}
+	*v = n
+	return nil
+}
+
+func parseBool(value string, v *bool) error {
+	switch value {
+	case "true":
+		*v = true
+	case "false":
+		*v = false
+	default:
+		return fmt.Errorf("expected true or false, got %s", value)
+	}
+	return nil
+}
+
+// stripComment drops a # comment that isn't inside a string
+func stripComment(line string) string {
+	quoted := false
+	for i, r := range line {
+		switch {
+		case r == '"' && (i == 0 || line[i-1] != '\\'):
+			quoted = !quoted
+		case r == '#' && !quoted:
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// formatConfig writes the settings out as a config file
+func formatConfig(s settings) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "variant = %q\n", s.variant)
+	fmt.Fprintf(&b, "theme = %q\n", s.theme)
+	fmt.Fprintf(&b, "data_dir = %q\n", s.dataDir)
+	fmt.Fprintf(&b, "life = %d\n", s.startLife)
+	fmt.Fprintf(&b, "degradation = %q\n", s.degradation)
+	fmt.Fprintf(&b, "shuffle = %q\n", s.shuffle)
+	fmt.Fprintf(&b, "animations = %t\n", s.animations)
+	fmt.Fprintf(&b, "carry_weapon = %t\n", s.carryWeapon)
+	fmt.Fprintf(&b, "confirm_lethal = %t\n", s.confirmLethal)
+	fmt.Fprintf(&b, "show_counter = %t\n", s.showCounter)
+	fmt.Fprintf(&b, "ascii = %t\n", s.ascii)
+	fmt.Fprintf(&b, "accessible = %t\n", s.accessible)
+
+	b.WriteString("\n[keys]\n")
+	for _, action := range s.keys.actions() {
+		var keys []string
+		for _, key := range s.keys[action] {
+			keys = append(keys, strconv.Quote(key))
+		}
+		fmt.Fprintf(&b, "%s = [%s]\n", action, strings.Join(keys, ", "))
+	}
+	return b.String()
+}
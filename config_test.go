@@ -0,0 +1,127 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	config := `# my settings
+variant = "official"
+theme = "mono" # easier on the eyes
+data_dir = "/tmp/scoundrel"
+life = 25
+animations = false
+
+[keys]
+up = ["w", "up"]
+choose = ["enter", " "]
+`
+	s := defaultSettings()
+	if err := parseConfig(strings.NewReader(config), &s); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.theme != "mono" {
+		t.Errorf("expected theme to be mono, got %q", s.theme)
+	}
+	if s.dataDir != "/tmp/scoundrel" {
+		t.Errorf("expected data dir to be /tmp/scoundrel, got %q", s.dataDir)
+	}
+	if s.startLife != 25 {
+		t.Errorf("expected life to be 25, got %d", s.startLife)
+	}
+	if s.animations {
+		t.Errorf("expected animations to be off")
+	}
+	if !s.confirmLethal {
+		t.Errorf("expected confirm lethal to keep its default")
+	}
+	if got := s.keys.action("w"); got != keyUp {
+		t.Errorf("expected w to be bound to up, got %q", got)
+	}
+	if got := s.keys.action("k"); got != "" {
+		t.Errorf("expected k to be unbound, got %q", got)
+	}
+	if got := s.keys.action("j"); got != keyDown {
+		t.Errorf("expected j to keep its binding, got %q", got)
+	}
+}
+
+// A key can move to another action if the first gives it up, in either order
+func TestParseConfigMovedKey(t *testing.T) {
+	s := defaultSettings()
+	if err := parseConfig(strings.NewReader("[keys]\nback = [\"k\"]\nup = [\"up\"]"), &s); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.keys.action("k"); got != keyBack {
+		t.Errorf("expected k to be bound to back, got %q", got)
+	}
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	tests := []struct {
+		config string
+		err    string
+	}{
+		{`colour = "red"`, `1: unknown setting "colour"`},
+		{"\n\nanimations = yes", "3: expected true or false"},
+		{"theme = mono", "1: expected a quoted string"},
+		{"life = lots", "1: expected a whole number"},
+		{"theme", "1: expected name = value"},
+		{"[rules]", `1: unknown table "rules"`},
+		{"[keys]\njump = [\"space\"]", `2: unknown action "jump"`},
+		{"[keys]\nup = \"w\"", "2: keys for up should be a list"},
+		{"[keys]\nback = [\"k\"]", `2: "k" is bound to both back and up`},
+		{"[keys]\nup = [\"w\"]\n\ndown = [\"w\", \"s\"]", `4: "w" is bound to both down and up`},
+	}
+
+	for _, test := range tests {
+		s := defaultSettings()
+		err := parseConfig(strings.NewReader(test.config), &s)
+		if err == nil || !strings.HasPrefix(err.Error(), test.err) {
+			t.Errorf("expected error %q for %q, got %v", test.err, test.config, err)
+		}
+	}
+}
+
+func TestFormatConfig(t *testing.T) {
+	s := defaultSettings()
+	s.theme = "high-contrast"
+	s.showCounter = true
+	s.shuffle = Shuffle{Kind: riffleShuffle, Times: 3}
+	s.keys[keyBack] = []string{"x"}
+
+	got := defaultSettings()
+	if err := parseConfig(strings.NewReader(formatConfig(s)), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, s) {
+		t.Errorf("expected %+v, got %+v", s, got)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	s := defaultSettings()
+
+	// A missing file leaves the defaults
+	if err := loadConfig(filepath.Join(dir, configFile), &s); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s, defaultSettings()) {
+		t.Errorf("expected default settings, got %+v", s)
+	}
+
+	path := filepath.Join(dir, configFile)
+	if err := os.WriteFile(path, []byte("theme = 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err := loadConfig(path, &s)
+	if err == nil || !strings.Contains(err.Error(), path+":1:") {
+		t.Errorf("expected the error to name the file and line, got %v", err)
+	}
+}
@@ -95,8 +95,20 @@ func absRank(c Card) int {
 var shuffleRand = rand.New(rand.NewSource(time.Now().Unix()))
 
 func Shuffle(cards []Card) []Card {
+	return shuffle(cards, shuffleRand)
+}
+
+// SeededShuffle shuffles with its own source seeded with seed, so the same
+// seed always deals the same order
+func SeededShuffle(seed int64) func([]Card) []Card {
+	return func(cards []Card) []Card {
+		return shuffle(cards, rand.New(rand.NewSource(seed)))
+	}
+}
+
+func shuffle(cards []Card, r *rand.Rand) []Card {
 	ret := make([]Card, len(cards))
-	perm := shuffleRand.Perm(len(cards))
+	perm := r.Perm(len(cards))
 	for i, j := range perm {
 		ret[i] = cards[j]
 	}
@@ -115,16 +127,12 @@ func Jokers(n int) func([]Card) []Card {
 	}
 }
 
+// Filter takes out the cards f is true for.
+//
+// Deprecated: use Remove, or Keep for the opposite, which say which way they
+// filter.
 func Filter(f func(card Card) bool) func([]Card) []Card {
-	return func(cards []Card) []Card {
-		var ret []Card
-		for _, c := range cards {
-			if !f(c) {
-				ret = append(ret, c)
-			}
-		}
-		return ret
-	}
+	return Remove(f)
 }
 
 func Deck(n int) func([]Card) []Card {
@@ -63,6 +63,25 @@ func TestShuffle(t *testing.T) {
 	}
 }
 
+func TestSeededShuffle(t *testing.T) {
+	first := New(SeededShuffle(42))
+	second := New(SeededShuffle(42))
+	other := New(SeededShuffle(43))
+
+	same := true
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Expected the same seed to deal the same order, card %d was %s and %s.", i, first[i], second[i])
+		}
+		if first[i] != other[i] {
+			same = false
+		}
+	}
+	if same {
+		t.Error("Expected different seeds to deal different orders.")
+	}
+}
+
 func TestJokers(t *testing.T) {
 	cards := New(Jokers(4))
 	count := 0
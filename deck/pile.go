@@ -0,0 +1,55 @@
+package deck
+
+import "slices"
+
+// Pile is a stack of cards with the top card first. It's a plain slice, so
+// it can be indexed, ranged over and stored as JSON like one, while its
+// methods move cards on and off it without copying more than they have to.
+type Pile []Card
+
+// Len is how many cards are in the pile
+func (p Pile) Len() int {
+	return len(p)
+}
+
+// Peek returns up to n cards from the top without taking them. They share
+// the pile's storage, so they shouldn't be changed.
+func (p Pile) Peek(n int) Pile {
+	n = min(max(n, 0), len(p))
+	return p[:n:n]
+}
+
+// Draw takes up to n cards from the top. The cards drawn share the pile's
+// old storage, which the pile never writes to again.
+func (p *Pile) Draw(n int) Pile {
+	drawn := p.Peek(n)
+	*p = (*p)[len(drawn):]
+	return drawn
+}
+
+// PutTop puts cards on top of the pile, the first of them on top
+func (p *Pile) PutTop(cards ...Card) {
+	*p = slices.Insert(*p, 0, cards...)
+}
+
+// PutBottom puts cards under the pile, the last of them at the bottom
+func (p *Pile) PutBottom(cards ...Card) {
+	*p = append(*p, cards...)
+}
+
+// Remove takes the card at i, counting from the top
+func (p *Pile) Remove(i int) Card {
+	c := (*p)[i]
+	*p = slices.Delete(*p, i, i+1)
+	return c
+}
+
+// Cut moves the top n cards to the bottom, in place
+func (p Pile) Cut(n int) {
+	if n <= 0 || n >= len(p) {
+		return
+	}
+	slices.Reverse(p[:n])
+	slices.Reverse(p[n:])
+	slices.Reverse(p)
+}
@@ -0,0 +1,98 @@
+package deck
+
+import (
+	"fmt"
+	"testing"
+)
+
+func pileOf(t *testing.T, cards ...string) Pile {
+	t.Helper()
+	var p Pile
+	for _, s := range cards {
+		c, err := ParseCard(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p = append(p, c)
+	}
+	return p
+}
+
+func assertPile(t *testing.T, p Pile, expected string) {
+	t.Helper()
+	var shorts []string
+	for _, c := range p {
+		shorts = append(shorts, c.Short())
+	}
+	if got := fmt.Sprint(shorts); got != expected {
+		t.Errorf("Expected pile %s, received %s.", expected, got)
+	}
+}
+
+func TestPileDraw(t *testing.T) {
+	p := pileOf(t, "AS", "2S", "3S", "4S")
+
+	assertPile(t, p.Peek(2), "[AS 2S]")
+	assertPile(t, p, "[AS 2S 3S 4S]")
+
+	drawn := p.Draw(3)
+	assertPile(t, drawn, "[AS 2S 3S]")
+	assertPile(t, p, "[4S]")
+
+	drawn = p.Draw(3)
+	assertPile(t, drawn, "[4S]")
+	if p.Len() != 0 {
+		t.Errorf("Expected an empty pile, received %d cards.", p.Len())
+	}
+}
+
+func TestPilePut(t *testing.T) {
+	p := pileOf(t, "2H", "3H")
+	p.PutTop(pileOf(t, "AH", "KH")...)
+	p.PutBottom(pileOf(t, "4H", "5H")...)
+	assertPile(t, p, "[AH KH 2H 3H 4H 5H]")
+}
+
+func TestPileRemove(t *testing.T) {
+	p := pileOf(t, "AC", "2C", "3C")
+	if c := p.Remove(1); c.Short() != "2C" {
+		t.Errorf("Expected to remove 2C, received %s.", c.Short())
+	}
+	assertPile(t, p, "[AC 3C]")
+}
+
+func TestPileCut(t *testing.T) {
+	p := pileOf(t, "AD", "2D", "3D", "4D", "5D")
+	p.Cut(2)
+	assertPile(t, p, "[3D 4D 5D AD 2D]")
+
+	p.Cut(0)
+	p.Cut(5)
+	assertPile(t, p, "[3D 4D 5D AD 2D]")
+}
+
+// Cards drawn from a pile mustn't change whatever is put back on it
+func TestPileNoAliasing(t *testing.T) {
+	p := pileOf(t, "AS", "2S", "3S", "4S", "5S")
+	drawn := p.Draw(2)
+
+	p.PutTop(pileOf(t, "KH")...)
+	p.PutBottom(pileOf(t, "QH", "JH")...)
+	drawn = append(drawn, pileOf(t, "10H")...)
+
+	assertPile(t, drawn, "[AS 2S 10H]")
+	assertPile(t, p, "[KH 3S 4S 5S QH JH]")
+}
+
+func TestPileAllocations(t *testing.T) {
+	p := Pile(New())
+	allocs := testing.AllocsPerRun(100, func() {
+		q := p
+		q.Cut(20)
+		_ = q.Peek(4)
+		_ = q.Draw(4)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected drawing, peeking and cutting not to allocate, received %.0f allocations.", allocs)
+	}
+}
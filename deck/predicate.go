@@ -0,0 +1,88 @@
+package deck
+
+import "slices"
+
+// Predicate reports whether a card is one of those wanted
+type Predicate func(Card) bool
+
+// Remove is an option for New that takes out the cards matching p
+func Remove(p Predicate) func([]Card) []Card {
+	return Keep(Not(p))
+}
+
+// Keep is an option for New that keeps only the cards matching p
+func Keep(p Predicate) func([]Card) []Card {
+	return func(cards []Card) []Card {
+		var ret []Card
+		for _, c := range cards {
+			if p(c) {
+				ret = append(ret, c)
+			}
+		}
+		return ret
+	}
+}
+
+// BySuit matches cards of any of the suits
+func BySuit(suits ...Suit) Predicate {
+	return func(c Card) bool {
+		return slices.Contains(suits, c.Suit)
+	}
+}
+
+// ByRank matches cards of any of the ranks. Jokers have no rank and never
+// match.
+func ByRank(ranks ...Rank) Predicate {
+	return func(c Card) bool {
+		return c.Suit != Joker && slices.Contains(ranks, c.Rank)
+	}
+}
+
+// RankBetween matches cards from low to high, both included, with the Ace
+// low. Jokers never match.
+func RankBetween(low, high Rank) Predicate {
+	return func(c Card) bool {
+		return c.Suit != Joker && c.Rank >= low && c.Rank <= high
+	}
+}
+
+// IsFace matches Jacks, Queens and Kings
+func IsFace(c Card) bool {
+	return c.Suit != Joker && c.Rank >= Jack && c.Rank <= King
+}
+
+// IsRed matches hearts and diamonds
+func IsRed(c Card) bool {
+	return c.Suit == Heart || c.Suit == Diamond
+}
+
+// And matches cards matching all of ps
+func And(ps ...Predicate) Predicate {
+	return func(c Card) bool {
+		for _, p := range ps {
+			if !p(c) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or matches cards matching any of ps
+func Or(ps ...Predicate) Predicate {
+	return func(c Card) bool {
+		for _, p := range ps {
+			if p(c) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not matches cards p doesn't
+func Not(p Predicate) Predicate {
+	return func(c Card) bool {
+		return !p(c)
+	}
+}
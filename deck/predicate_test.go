@@ -0,0 +1,66 @@
+package deck
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleRemove() {
+	// A Scoundrel dungeon has no red faces or red Aces
+	cards := New(Remove(And(IsRed, Or(IsFace, ByRank(Ace)))))
+	fmt.Println(len(cards))
+
+	// Output:
+	// 44
+}
+
+func TestKeep(t *testing.T) {
+	cards := New(Keep(And(BySuit(Spade), RankBetween(Two, Four))))
+	if fmt.Sprint(cards) != "[Two of Spades Three of Spades Four of Spades]" {
+		t.Errorf("Expected the Two to Four of Spades, received %v.", cards)
+	}
+}
+
+func TestPredicates(t *testing.T) {
+	queenOfHearts := Card{Suit: Heart, Rank: Queen}
+	twoOfClubs := Card{Suit: Club, Rank: Two}
+	joker := Card{Suit: Joker}
+
+	tests := []struct {
+		name     string
+		p        Predicate
+		card     Card
+		expected bool
+	}{
+		{"face", IsFace, queenOfHearts, true},
+		{"not face", IsFace, twoOfClubs, false},
+		{"red", IsRed, queenOfHearts, true},
+		{"black", IsRed, twoOfClubs, false},
+		{"suit", BySuit(Club, Spade), twoOfClubs, true},
+		{"rank", ByRank(Queen, King), queenOfHearts, true},
+		{"joker has no rank", ByRank(0), joker, false},
+		{"joker is no face", IsFace, joker, false},
+		{"between", RankBetween(Ace, Two), twoOfClubs, true},
+		{"not between", RankBetween(Three, Ten), twoOfClubs, false},
+		{"and", And(IsRed, IsFace), queenOfHearts, true},
+		{"and fails", And(IsRed, IsFace), twoOfClubs, false},
+		{"or", Or(IsRed, IsFace), twoOfClubs, false},
+		{"not", Not(IsRed), twoOfClubs, true},
+		{"empty and", And(), joker, true},
+		{"empty or", Or(), joker, false},
+	}
+
+	for _, test := range tests {
+		if got := test.p(test.card); got != test.expected {
+			t.Errorf("Expected %s on the %s to be %t, received %t.", test.name, test.card, test.expected, got)
+		}
+	}
+}
+
+func TestRemoveMatchesFilter(t *testing.T) {
+	removed := New(Remove(IsFace))
+	filtered := New(Filter(IsFace))
+	if fmt.Sprint(removed) != fmt.Sprint(filtered) {
+		t.Error("Expected Remove to take out the same cards as Filter.")
+	}
+}
@@ -0,0 +1,83 @@
+package deck
+
+import "math/rand"
+
+// Riffle shuffles the way most people do, splitting the cards in two and
+// riffling them together, times over. It follows the Gilbert–Shannon–Reeds
+// model: the split is binomial and each card falls from either half in
+// proportion to how many are left in it. A few riffles leave runs of the old
+// order behind, which is what makes them feel dealt by hand.
+func Riffle(times int, seed int64) func([]Card) []Card {
+	return func(cards []Card) []Card {
+		r := rand.New(rand.NewSource(seed))
+		for range times {
+			cards = riffle(cards, r)
+		}
+		return cards
+	}
+}
+
+func riffle(cards []Card, r *rand.Rand) []Card {
+	split := binomial(len(cards), r)
+	left, right := cards[:split], cards[split:]
+
+	ret := make([]Card, 0, len(cards))
+	for len(left) > 0 || len(right) > 0 {
+		if r.Intn(len(left)+len(right)) < len(left) {
+			ret = append(ret, left[0])
+			left = left[1:]
+		} else {
+			ret = append(ret, right[0])
+			right = right[1:]
+		}
+	}
+	return ret
+}
+
+// Overhand shuffles by sliding small packets off the top of the cards onto
+// a new pile, times over. Each packet ends after a card with a chance of one
+// in four, so packets are usually a handful of cards.
+func Overhand(times int, seed int64) func([]Card) []Card {
+	return func(cards []Card) []Card {
+		r := rand.New(rand.NewSource(seed))
+		for range times {
+			cards = overhand(cards, r)
+		}
+		return cards
+	}
+}
+
+func overhand(cards []Card, r *rand.Rand) []Card {
+	ret := make([]Card, len(cards))
+	end := len(ret)
+	start := 0
+	for i := range cards {
+		if i == len(cards)-1 || r.Intn(4) == 0 {
+			// The packet lands on top of those already slid off
+			packet := cards[start : i+1]
+			end -= len(packet)
+			copy(ret[end:], packet)
+			start = i + 1
+		}
+	}
+	return ret
+}
+
+// Cut moves the top part of the cards to the bottom, cutting near the middle
+// the way a person would
+func Cut(seed int64) func([]Card) []Card {
+	return func(cards []Card) []Card {
+		r := rand.New(rand.NewSource(seed))
+		Pile(cards).Cut(binomial(len(cards), r))
+		return cards
+	}
+}
+
+// binomial counts heads in n fair coin tosses
+func binomial(n int, r *rand.Rand) int {
+	heads := 0
+	for range n {
+		heads += r.Intn(2)
+	}
+	return heads
+}
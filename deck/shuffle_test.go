@@ -0,0 +1,99 @@
+package deck
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func ExampleRiffle() {
+	cards := New(Keep(BySuit(Spade)), Riffle(1, 7))
+	fmt.Println(Pile(cards).Peek(6))
+
+	// Output:
+	// [Four of Spades Five of Spades Six of Spades Ace of Spades Seven of Spades Eight of Spades]
+}
+
+func TestShuffles(t *testing.T) {
+	shuffles := map[string]func(int64) func([]Card) []Card{
+		"riffle":   func(seed int64) func([]Card) []Card { return Riffle(3, seed) },
+		"overhand": func(seed int64) func([]Card) []Card { return Overhand(10, seed) },
+		"cut":      Cut,
+	}
+	for name, shuffle := range shuffles {
+		t.Run(name, func(t *testing.T) {
+			cards := New(shuffle(42))
+			if len(cards) != 52 {
+				t.Fatalf("Expected 52 cards, received %d.", len(cards))
+			}
+			if slices.Equal(cards, New()) {
+				t.Errorf("Expected the cards to be shuffled.")
+			}
+			if !slices.Equal(cards, New(shuffle(42))) {
+				t.Errorf("Expected the same seed to shuffle the same way.")
+			}
+			if slices.Equal(cards, New(shuffle(1))) {
+				t.Errorf("Expected another seed to shuffle another way.")
+			}
+
+			sorted := slices.Clone(cards)
+			DefaultSort(sorted)
+			if !slices.Equal(sorted, New()) {
+				t.Errorf("Expected the same cards, received %v.", sorted)
+			}
+		})
+	}
+}
+
+// risingSequences counts the runs of a deck's old order that are still
+// interleaved in the cards, the telltale of a riffle
+func risingSequences(cards []Card) int {
+	pos := map[Card]int{}
+	for i, c := range cards {
+		pos[c] = i
+	}
+	runs := 1
+	for i, c := range New()[1:] {
+		if pos[c] < pos[New()[i]] {
+			runs++
+		}
+	}
+	return runs
+}
+
+func TestRiffleRisingSequences(t *testing.T) {
+	for riffles := 1; riffles <= 3; riffles++ {
+		for seed := range int64(20) {
+			runs := risingSequences(New(Riffle(riffles, seed)))
+			if runs > 1<<riffles {
+				t.Errorf("Expected at most %d rising sequences after %d riffles, received %d.", 1<<riffles, riffles, runs)
+			}
+		}
+	}
+}
+
+func TestOverhandPackets(t *testing.T) {
+	// An overhand shuffle moves packets of a few cards each, which stay in
+	// their old order
+	cards := New(Overhand(1, 1))
+	kept := 0
+	for i := range cards[1:] {
+		if pos := slices.Index(New(), cards[i]); pos+1 < len(cards) && New()[pos+1] == cards[i+1] {
+			kept++
+		}
+	}
+	if kept < len(cards)/2 {
+		t.Errorf("Expected most cards to stay by their neighbours, received %d of %d.", kept, len(cards)-1)
+	}
+	if kept == len(cards)-1 {
+		t.Errorf("Expected the packets to be moved.")
+	}
+}
+
+func TestCutKeepsOrder(t *testing.T) {
+	cards := New(Cut(5))
+	n := slices.Index(cards, New()[0])
+	if !slices.Equal(append(cards[n:], cards[:n]...), New()) {
+		t.Errorf("Expected a cut to keep the cards in order, received %v.", cards)
+	}
+}
@@ -0,0 +1,218 @@
+package deck
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var suitLetters = [...]string{Spade: "S", Diamond: "D", Club: "C", Heart: "H"}
+
+var rankLetters = map[Rank]string{Ace: "A", Jack: "J", Queen: "Q", King: "K"}
+
+// Short writes a card in its short form, the rank then the suit letter like
+// "7C", "10H" or "AS". Jokers are "JOKER", numbered from the second on as
+// "JOKER1", "JOKER2" and so on. The zero Card, which is no card at all, is
+// empty.
+func (c Card) Short() string {
+	if c == (Card{}) {
+		return ""
+	}
+	if c.Suit == Joker {
+		if c.Rank == 0 {
+			return "JOKER"
+		}
+		return "JOKER" + strconv.Itoa(int(c.Rank))
+	}
+	return shortRank(c.Rank) + shortSuit(c.Suit)
+}
+
+func shortRank(r Rank) string {
+	if s, ok := rankLetters[r]; ok {
+		return s
+	}
+	return strconv.Itoa(int(r))
+}
+
+func shortSuit(s Suit) string {
+	if s == Joker {
+		return "JOKER"
+	}
+	return suitLetters[s]
+}
+
+// ParseCard reads a card in its short form, like "7C", "10h" or "JOKER", or
+// written out the way String writes it, like "Seven of Clubs"
+func ParseCard(s string) (Card, error) {
+	s = strings.TrimSpace(s)
+	upper := strings.ToUpper(s)
+
+	if n, ok := strings.CutPrefix(upper, "JOKER"); ok {
+		if n == "" {
+			return Card{Suit: Joker}, nil
+		}
+		i, err := strconv.Atoi(n)
+		if err != nil || i < 0 || i > 255 {
+			return Card{}, fmt.Errorf("deck: unknown joker %q", s)
+		}
+		return Card{Suit: Joker, Rank: Rank(i)}, nil
+	}
+
+	if rank, suit, ok := strings.Cut(s, " of "); ok {
+		r, err := ParseRank(rank)
+		if err != nil {
+			return Card{}, err
+		}
+		su, err := ParseSuit(strings.TrimSuffix(suit, "s"))
+		if err != nil {
+			return Card{}, err
+		}
+		return Card{Suit: su, Rank: r}, nil
+	}
+
+	if len(s) < 2 {
+		return Card{}, fmt.Errorf("deck: unknown card %q", s)
+	}
+	r, err := ParseRank(s[:len(s)-1])
+	if err != nil {
+		return Card{}, fmt.Errorf("deck: unknown card %q", s)
+	}
+	su, err := ParseSuit(s[len(s)-1:])
+	if err != nil || su == Joker {
+		return Card{}, fmt.Errorf("deck: unknown card %q", s)
+	}
+	return Card{Suit: su, Rank: r}, nil
+}
+
+// ParseRank reads a rank as a number, a letter like "K" or a name like
+// "King"
+func ParseRank(s string) (Rank, error) {
+	s = strings.TrimSpace(s)
+	for r := minRank; r <= maxRank; r++ {
+		if strings.EqualFold(s, shortRank(r)) || strings.EqualFold(s, r.String()) {
+			return r, nil
+		}
+	}
+	return 0, fmt.Errorf("deck: unknown rank %q", s)
+}
+
+// ParseSuit reads a suit as a letter like "S" or a name like "Spade"
+func ParseSuit(s string) (Suit, error) {
+	s = strings.TrimSpace(s)
+	for su := Spade; su <= Joker; su++ {
+		if strings.EqualFold(s, shortSuit(su)) || strings.EqualFold(s, su.String()) {
+			return su, nil
+		}
+	}
+	return 0, fmt.Errorf("deck: unknown suit %q", s)
+}
+
+func (c Card) MarshalText() ([]byte, error) {
+	return []byte(c.Short()), nil
+}
+
+func (c *Card) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*c = Card{}
+		return nil
+	}
+	card, err := ParseCard(string(text))
+	if err != nil {
+		return err
+	}
+	*c = card
+	return nil
+}
+
+// UnmarshalJSON reads a card in its short form, or as an object of its suit
+// and rank numbers the way cards were written before they had a text form
+func (c *Card) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		var old struct {
+			Suit uint8
+			Rank uint8
+		}
+		if err := json.Unmarshal(data, &old); err != nil {
+			return err
+		}
+		*c = Card{Suit: Suit(old.Suit), Rank: Rank(old.Rank)}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return c.UnmarshalText([]byte(s))
+}
+
+func (s Suit) MarshalText() ([]byte, error) {
+	if s > Joker {
+		return nil, fmt.Errorf("deck: unknown suit %d", s)
+	}
+	return []byte(shortSuit(s)), nil
+}
+
+func (s *Suit) UnmarshalText(text []byte) error {
+	su, err := ParseSuit(string(text))
+	if err != nil {
+		return err
+	}
+	*s = su
+	return nil
+}
+
+func (r Rank) MarshalText() ([]byte, error) {
+	return []byte(shortRank(r)), nil
+}
+
+func (r *Rank) UnmarshalText(text []byte) error {
+	rank, err := ParseRank(string(text))
+	if err != nil {
+		return err
+	}
+	*r = rank
+	return nil
+}
+
+// CardBack is the Unicode glyph of a card lying face down
+const CardBack = "\U0001F0A0"
+
+var suitSymbols = [...]string{Spade: "♠", Diamond: "♦", Club: "♣", Heart: "♥", Joker: "🃏"}
+
+// Symbol returns the symbol of the suit, ♠ ♦ ♣ or ♥, or 🃏 for jokers
+func (s Suit) Symbol() string {
+	if s > Joker {
+		return "?"
+	}
+	return suitSymbols[s]
+}
+
+// suitGlyphs are where each suit starts in the Unicode Playing Cards block
+var suitGlyphs = [...]rune{Spade: 0x1F0A0, Heart: 0x1F0B0, Diamond: 0x1F0C0, Club: 0x1F0D0}
+
+// jokerGlyphs are the black, red and white jokers, in the order jokers are
+// numbered
+var jokerGlyphs = [...]rune{0x1F0CF, 0x1F0BF, 0x1F0DF}
+
+// Glyph returns the card's glyph from the Unicode Playing Cards block, like
+// 🂡 for the Ace of Spades. Jokers take the black, red and white joker glyphs
+// in turn, and the zero Card is the back of a card.
+func (c Card) Glyph() string {
+	switch {
+	case c == (Card{}):
+		return CardBack
+	case c.Suit == Joker:
+		return string(jokerGlyphs[int(c.Rank)%len(jokerGlyphs)])
+	case c.Suit > Joker || c.Rank < minRank || c.Rank > maxRank:
+		return "?"
+	}
+
+	// The block has a Knight between the Jack and the Queen
+	offset := rune(c.Rank)
+	if c.Rank >= Queen {
+		offset++
+	}
+	return string(suitGlyphs[c.Suit] + offset)
+}
@@ -0,0 +1,137 @@
+package deck
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func ExampleParseCard() {
+	for _, s := range []string{"7C", "10h", "AS", "QD", "JOKER", "Seven of Clubs"} {
+		c, _ := ParseCard(s)
+		fmt.Println(c.Short(), c)
+	}
+
+	// Output:
+	// 7C Seven of Clubs
+	// 10H Ten of Hearts
+	// AS Ace of Spades
+	// QD Queen of Diamonds
+	// JOKER Joker
+	// 7C Seven of Clubs
+}
+
+func TestParseCardRoundTrip(t *testing.T) {
+	cards := New(Jokers(3))
+	for _, c := range cards {
+		parsed, err := ParseCard(c.Short())
+		if err != nil {
+			t.Fatalf("Expected %s to parse, received %v.", c.Short(), err)
+		}
+		if parsed != c {
+			t.Errorf("Expected %s, received %s.", c, parsed)
+		}
+
+		parsed, err = ParseCard(c.String())
+		if err != nil || parsed.Suit != c.Suit || (c.Suit != Joker && parsed != c) {
+			t.Errorf("Expected %q to parse as %s, received %s (%v).", c.String(), c, parsed, err)
+		}
+	}
+}
+
+func TestParseCardErrors(t *testing.T) {
+	for _, s := range []string{"", "S", "1S", "11S", "10X", "JOKERX", "Eleven of Clubs"} {
+		if _, err := ParseCard(s); err == nil {
+			t.Errorf("Expected %q not to parse.", s)
+		}
+	}
+}
+
+func TestCardJSON(t *testing.T) {
+	hand := []Card{{Rank: Seven, Suit: Club}, {Suit: Joker, Rank: 1}, {}}
+	data, err := json.Marshal(hand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `["7C","JOKER1",""]` {
+		t.Errorf("Expected [\"7C\",\"JOKER1\",\"\"], received %s.", data)
+	}
+
+	var parsed []Card
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(parsed) != fmt.Sprint(hand) {
+		t.Errorf("Expected %v, received %v.", hand, parsed)
+	}
+
+	// Cards used to be written as their suit and rank numbers
+	var old Card
+	if err := json.Unmarshal([]byte(`{"Suit":2,"Rank":7}`), &old); err != nil {
+		t.Fatal(err)
+	}
+	if old != (Card{Rank: Seven, Suit: Club}) {
+		t.Errorf("Expected the Seven of Clubs, received %s.", old)
+	}
+}
+
+func TestSuitAndRankJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Suit Suit
+		Rank Rank
+	}{Heart, Queen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"Suit":"H","Rank":"Q"}` {
+		t.Errorf("Expected {\"Suit\":\"H\",\"Rank\":\"Q\"}, received %s.", data)
+	}
+
+	var parsed struct {
+		Suit Suit
+		Rank Rank
+	}
+	if err := json.Unmarshal([]byte(`{"Suit":"Spade","Rank":"10"}`), &parsed); err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Suit != Spade || parsed.Rank != Ten {
+		t.Errorf("Expected Spade and Ten, received %s and %s.", parsed.Suit, parsed.Rank)
+	}
+}
+
+func ExampleCard_Glyph() {
+	fmt.Println(Card{Rank: Ace, Suit: Spade}.Glyph())
+	fmt.Println(Card{Rank: Jack, Suit: Heart}.Glyph())
+	fmt.Println(Card{Rank: Queen, Suit: Diamond}.Glyph())
+	fmt.Println(Card{Rank: King, Suit: Club}.Glyph())
+	fmt.Println(Card{Suit: Joker}.Glyph())
+	fmt.Println(Card{}.Glyph())
+
+	// Output:
+	// 🂡
+	// 🂻
+	// 🃍
+	// 🃞
+	// 🃏
+	// 🂠
+}
+
+func TestGlyph(t *testing.T) {
+	seen := map[string]bool{}
+	for _, c := range New(Jokers(3)) {
+		g := c.Glyph()
+		if seen[g] {
+			t.Errorf("Expected each card to have its own glyph, %s repeats %s.", c, g)
+		}
+		seen[g] = true
+	}
+}
+
+func TestSymbol(t *testing.T) {
+	expected := map[Suit]string{Spade: "♠", Heart: "♥", Diamond: "♦", Club: "♣", Joker: "🃏"}
+	for s, symbol := range expected {
+		if s.Symbol() != symbol {
+			t.Errorf("Expected %s to be %s, received %s.", s, symbol, s.Symbol())
+		}
+	}
+}
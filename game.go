@@ -6,40 +6,33 @@ import (
 	"github.com/andrewdaoust/scoundrel/deck"
 )
 
-func newDungeon() []deck.Card {
-	d := deck.New(
-		deck.Filter(func(c deck.Card) bool {
-			if c.Suit == deck.Heart || c.Suit == deck.Diamond {
-				if c.Rank == deck.Ace || c.Rank == deck.King || c.Rank == deck.Queen || c.Rank == deck.Jack {
-					return true
-				}
-			}
-			return false
-		}),
-		deck.Shuffle,
-	)
-	return d
+func newDungeon(seed int64, r Rules) []deck.Card {
+	d := deck.Jokers(r.Jokers)(r.composition().cards())
+	return r.Shuffle.shuffle(seed)(d)
 }
 
 func (m *model) drawToRoom(n int) {
-	if len(m.dungeon) >= n {
-		m.room = append(m.room, m.dungeon[:n]...)
-		m.dungeon = m.dungeon[n:]
-	} else {
-		m.room = append(m.room, m.dungeon...)
-		m.dungeon = []deck.Card{}
-	}
+	// Only the cards already in the room stay dealt
+	m.dealt = min(m.dealt, len(m.room))
+	m.room.PutBottom(m.dungeon.Draw(n)...)
 }
 
 func (m *model) usePotion(c deck.Card) {
-	m.life = min(20, m.life+int(c.Rank))
+	before := m.life
+	m.life = min(m.rules.MaxLife, m.life+m.potionHealing(c))
+	m.log(eventPotion, c, before)
+	m.roomPotions++
+	m.lastPotion = c
 }
 
 func (m *model) equipWeapon(c deck.Card) {
+	previous := m.weapon
 	m.weapon = weapon{
 		card:  c,
-		slain: []deck.Card{},
+		slain: deck.Pile{},
 	}
+	m.log(eventEquip, c, m.life)
+	m.relicsEquipped(c, previous)
 }
 
 type attackType int
@@ -49,60 +42,92 @@ const (
 	withWeapon
 )
 
-func attackStrength(c deck.Card) int {
-	rank := int(c.Rank)
-	// Scale Ace to 14 for attacks
-	if rank == 1 {
-		rank = 14
-	}
-	return rank
+func (m model) fistDamage(c deck.Card) int {
+	return m.relicDamage(c, withFists, m.rules.strength(c))
 }
 
 func (m *model) attackWithFists(c deck.Card) {
-	m.life = max(0, m.life-attackStrength(c))
+	before := m.life
+	m.life = max(0, m.life-m.fistDamage(c))
+	m.log(eventFists, c, before)
+	m.relicsSlain(c, withFists)
 }
 
 func (m *model) canUseWeapon(c deck.Card) bool {
 	// No weapon equipped
-	if m.weapon.card.Rank == 0 {
+	if !m.weapon.equipped() {
 		return false
 	}
 
 	// Weapon unused, can use any card
-	if len(m.weapon.slain) == 0 {
+	limit, limited := m.weaponLimit()
+	if !limited {
 		return true
 	}
 
-	// Check if card rank is less than or equal to last slain
+	// Check the monster is weak enough for the last slain
+	return m.rules.canHit(m.rules.strength(c), limit)
+}
+
+// weaponLimit returns the strongest monster the equipped weapon can still
+// hit, and false if the weapon hasn't been used yet and can hit anything
+func (m model) weaponLimit() (int, bool) {
+	if len(m.weapon.slain) == 0 {
+		return 0, false
+	}
 	last := m.weapon.slain[len(m.weapon.slain)-1]
-	return attackStrength(c) <= attackStrength(last)
+	return m.rules.strength(last), true
+}
+
+// weaponPower is how much damage the equipped weapon takes off a hit
+func (m model) weaponPower() int {
+	return m.rules.value(m.weapon.card)
+}
+
+func (m model) weaponDamage(c deck.Card) int {
+	return m.relicDamage(c, withWeapon, max(0, m.rules.strength(c)-m.weaponPower()))
 }
 
 func (m *model) attackWithWeapon(c deck.Card) {
-	attack := max(0, attackStrength(c)-int(m.weapon.card.Rank))
-	m.life = max(0, m.life-attack)
-	m.weapon.slain = append(m.weapon.slain, c)
+	before := m.life
+	m.life = max(0, m.life-m.weaponDamage(c))
+	m.weapon.slain.PutBottom(c)
+	m.log(eventWeapon, c, before)
+	m.relicsSlain(c, withWeapon)
 }
 
 func (m *model) skipRoom() {
 	m.selection = 0
-	m.dungeon = append(m.dungeon, m.room...)
-	m.room = []deck.Card{}
+	m.dungeon.PutBottom(m.room.Draw(m.room.Len())...)
 	m.skippable = false
-	m.drawToRoom(4)
+	m.log(eventSkip, deck.Card{}, m.life)
+	m.drawToRoom(m.rules.RoomSize)
+	m.relicsSkipped()
+	m.roomDrawn()
 }
 
 func (m *model) discard() {
-	m.lastCard = m.room[m.selection]
+	m.lastCard = m.room.Remove(m.selection)
+	m.discarded.PutBottom(m.lastCard)
 	m.viewState = viewStateRoom
-	m.room = slices.Delete(m.room, m.selection, m.selection+1)
 	m.selection = 0
 	m.attackTypeSelection = 1
+	m.confirming = false
 	m.skippable = false
 
-	if len(m.room) == 1 {
-		m.drawToRoom(3)
+	// A room is cleared once, when the next is dealt or the last is emptied,
+	// and only if the player lived through it
+	refilling := len(m.room) == m.rules.RefillAt
+	dealing := refilling && len(m.dungeon) > 0
+	if m.life > 0 && (dealing || len(m.room) == 0) {
+		m.log(eventCleared, deck.Card{}, m.life)
+	}
+	if refilling {
+		m.drawToRoom(m.rules.RoomSize - m.rules.RefillAt)
 		m.skippable = true
+		if dealing {
+			m.roomDrawn()
+		}
 	}
 
 	m.gameOverCheck()
@@ -113,17 +138,54 @@ func (m *model) chooseAttack() {
 	if m.canUseWeapon(c) {
 		m.viewState = viewStateAttack
 	} else {
+		if !m.confirmLethal(m.fistDamage(c)) {
+			return
+		}
 		m.attackWithFists(c)
 		m.discard()
 	}
 }
 
+// lethal reports whether taking the damage would end the game
+func (m model) lethal(damage int) bool {
+	return m.life-damage <= 0
+}
+
+// lethalMonster reports whether every way of fighting the monster would end
+// the game
+func (m model) lethalMonster(c deck.Card) bool {
+	if !isMonster(c) {
+		return false
+	}
+	if m.canUseWeapon(c) && !m.lethal(m.weaponDamage(c)) {
+		return false
+	}
+	return m.lethal(m.fistDamage(c))
+}
+
+// confirmLethal reports whether a move dealing the damage can go ahead. A
+// lethal move has to be confirmed by playing it a second time.
+func (m *model) confirmLethal(damage int) bool {
+	if !m.settings.confirmLethal || !m.lethal(damage) || m.confirming {
+		m.confirming = false
+		return true
+	}
+	m.confirming = true
+	return false
+}
+
 func (m *model) playAttack() {
 	c := m.room[m.selection]
 	switch m.attackTypeSelection {
 	case int(withFists):
+		if !m.confirmLethal(m.fistDamage(c)) {
+			return
+		}
 		m.attackWithFists(c)
 	case int(withWeapon):
+		if !m.confirmLethal(m.weaponDamage(c)) {
+			return
+		}
 		m.attackWithWeapon(c)
 	default:
 		m.viewState = viewStateRoom
@@ -147,36 +209,92 @@ func (m *model) playRoom() {
 	case deck.Spade, deck.Club:
 		m.chooseAttack()
 		return
+	case deck.Joker:
+		m.chooseJoker()
+		return
 	}
 	m.discard()
 }
 
 func (m *model) gameOverCheck() {
+	if m.inPuzzle() && m.solved() {
+		m.viewState = viewStateGameOver
+		return
+	}
+	if m.inCampaign() && m.life > 0 && len(m.dungeon) == 0 && len(m.room) == 0 {
+		m.descend()
+		return
+	}
 	if m.life <= 0 || (len(m.dungeon) == 0 && len(m.room) == 0) {
 		m.viewState = viewStateGameOver
 	}
 }
 
+// won reports whether the whole dungeon was cleared alive
+func (m model) won() bool {
+	return m.life > 0 && len(m.dungeon) == 0 && len(m.room) == 0
+}
+
 func (m model) score() int {
-	score := m.life
+	life, penalty, bonus := m.scoreBreakdown()
+	return life - penalty + bonus
+}
+
+// scoreBreakdown splits the score into the life left, the penalty for the
+// cards left in the dungeon, and the bonus for finishing on a potion
+func (m model) scoreBreakdown() (int, int, int) {
 	if len(m.dungeon) > 0 {
-		for _, c := range append(m.dungeon, m.room...) {
-			score -= attackStrength(c)
+		penalty := 0
+		for _, c := range m.dungeon {
+			penalty += m.rules.strength(c)
 		}
-		return score
+		for _, c := range m.room {
+			penalty += m.rules.strength(c)
+		}
+		return m.life, penalty, 0
 	}
 
-	if m.lastCard.Suit == deck.Heart {
-		score += int(m.lastCard.Rank)
+	// A joker drunk last counts as a potion too, but not one wielded or fled
+	// with
+	if m.lastCard.Suit == deck.Heart || isJoker(m.lastCard) && m.lastCard == m.lastPotion {
+		return m.life, 0, m.rules.value(m.lastCard)
 	}
 
-	return score
+	return m.life, 0, 0
+}
+
+func isMonster(c deck.Card) bool {
+	return c.Suit == deck.Spade || c.Suit == deck.Club
+}
+
+// remainingDamage is the total strength of the monsters still in the room
+// and dungeon
+func (m model) remainingDamage() int {
+	damage := 0
+	for _, c := range m.room {
+		if isMonster(c) {
+			damage += m.rules.strength(c)
+		}
+	}
+	for _, c := range m.dungeon {
+		if isMonster(c) {
+			damage += m.rules.strength(c)
+		}
+	}
+	return damage
 }
 
-// func (m *model) newGame() {
-// 	newModel := initModel()
-// 	*m = newModel
-// }
+// remainingHealing is the total value of the potions still in the room and
+// dungeon, counting jokers as the potions they can be drunk as
+func (m model) remainingHealing() int {
+	healing := 0
+	for _, c := range slices.Concat(m.room, m.dungeon) {
+		if c.Suit == deck.Heart || isJoker(c) {
+			healing += m.rules.value(c)
+		}
+	}
+	return healing
+}
 
 func abs(x int) int {
 	if x < 0 {
@@ -195,7 +313,12 @@ func (m *model) up() {
 		m.selection = abs(m.selection - 1 + maxSelections) % maxSelections
 	case viewStateAttack:
 		m.attackTypeSelection = abs(m.attackTypeSelection - 1 + 3) % 3
+	case viewStateJoker:
+		m.jokerSelection = (m.jokerSelection - 1 + 4) % 4
+	case viewStateRelic:
+		m.relicSelection = (m.relicSelection + len(m.relicOffers)) % (len(m.relicOffers) + 1)
 	}
+	m.confirming = false
 }
 
 func (m *model) down() {
@@ -208,5 +331,10 @@ func (m *model) down() {
 		m.selection = abs(m.selection + 1) % maxSelections
 	case viewStateAttack:
 		m.attackTypeSelection = abs(m.attackTypeSelection + 1) % 3
+	case viewStateJoker:
+		m.jokerSelection = (m.jokerSelection + 1) % 4
+	case viewStateRelic:
+		m.relicSelection = (m.relicSelection + 1) % (len(m.relicOffers) + 1)
 	}
+	m.confirming = false
 }
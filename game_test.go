@@ -1,13 +1,19 @@
 package main
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/charmbracelet/x/ansi"
+
 	"github.com/andrewdaoust/scoundrel/deck"
 )
 
+// official are the rules most tests play by
+var official = presets["official"]
+
 func TestNewDungeon(t *testing.T) {
-	d := newDungeon()
+	d := newDungeon(1, official)
 	assertExpectedDungeonLength(t, len(d), 52-8)
 
 	for _, c := range d {
@@ -26,6 +32,7 @@ func TestDrawToRoom(t *testing.T) {
 	}{
 		{
 			m: model{
+				rules:   official,
 				dungeon: testDungeon(),
 				room:    []deck.Card{},
 			},
@@ -35,6 +42,7 @@ func TestDrawToRoom(t *testing.T) {
 		},
 		{
 			m: model{
+				rules:   official,
 				dungeon: testDungeon(),
 				room:    []deck.Card{{Suit: deck.Heart, Rank: 5}},
 			},
@@ -44,6 +52,7 @@ func TestDrawToRoom(t *testing.T) {
 		},
 		{
 			m: model{
+				rules: official,
 				dungeon: []deck.Card{
 					{Suit: deck.Spade, Rank: 2},
 					{Suit: deck.Spade, Rank: 3},
@@ -75,7 +84,7 @@ func TestUsePotion(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		m := model{life: test.initialLife}
+		m := model{rules: official, life: test.initialLife}
 		m.usePotion(test.potion)
 		assertExpectedLife(t, m.life, test.expected)
 	}
@@ -87,11 +96,12 @@ func TestEquipWeapon(t *testing.T) {
 		weaponCard deck.Card
 	}{
 		{
-			m:          model{},
+			m:          model{rules: official},
 			weaponCard: deck.Card{Suit: deck.Spade, Rank: 10},
 		},
 		{
 			m: model{
+				rules: official,
 				weapon: weapon{
 					card:  deck.Card{Suit: deck.Heart, Rank: 3},
 					slain: []deck.Card{{Suit: deck.Diamond, Rank: 5}},
@@ -133,7 +143,7 @@ func TestAttackStrength(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		result := attackStrength(test.card)
+		result := official.strength(test.card)
 		if result != test.expected {
 			t.Errorf("expected attack strength of %s to be %d, got %d", test.card.String(), test.expected, result)
 		}
@@ -146,10 +156,10 @@ func TestAttackWithFists(t *testing.T) {
 		c            deck.Card
 		expectedLife int
 	}{
-		{model{life: 20}, deck.Card{Rank: 5}, 15},
-		{model{life: 10}, deck.Card{Rank: 3}, 7},
-		{model{life: 4}, deck.Card{Rank: 10}, 0},
-		{model{life: 15}, deck.Card{Rank: deck.Ace}, 1},
+		{model{rules: official, life: 20}, deck.Card{Rank: 5}, 15},
+		{model{rules: official, life: 10}, deck.Card{Rank: 3}, 7},
+		{model{rules: official, life: 4}, deck.Card{Rank: 10}, 0},
+		{model{rules: official, life: 15}, deck.Card{Rank: deck.Ace}, 1},
 	}
 
 	for _, test := range tests {
@@ -165,32 +175,32 @@ func TestCanUseWeapon(t *testing.T) {
 		expected bool
 	}{
 		{
-			m:        model{weapon: weapon{card: deck.Card{Rank: 0}, slain: []deck.Card{}}},
+			m:        model{rules: official, weapon: weapon{card: deck.Card{Rank: 0}, slain: []deck.Card{}}},
 			c:        deck.Card{Rank: 5},
 			expected: false,
 		},
 		{
-			m:        model{weapon: weapon{card: deck.Card{Rank: 10}, slain: []deck.Card{}}},
+			m:        model{rules: official, weapon: weapon{card: deck.Card{Rank: 10}, slain: []deck.Card{}}},
 			c:        deck.Card{Rank: 5},
 			expected: true,
 		},
 		{
-			m:        model{weapon: weapon{card: deck.Card{Rank: 10}, slain: []deck.Card{}}},
+			m:        model{rules: official, weapon: weapon{card: deck.Card{Rank: 10}, slain: []deck.Card{}}},
 			c:        deck.Card{Rank: deck.Queen},
 			expected: true,
 		},
 		{
-			m:        model{weapon: weapon{card: deck.Card{Rank: 10}, slain: []deck.Card{{Rank: 3}}}},
+			m:        model{rules: official, weapon: weapon{card: deck.Card{Rank: 10}, slain: []deck.Card{{Rank: 3}}}},
 			c:        deck.Card{Rank: 5},
 			expected: false,
 		},
 		{
-			m:        model{weapon: weapon{card: deck.Card{Rank: 10}, slain: []deck.Card{{Rank: 3}}}},
+			m:        model{rules: official, weapon: weapon{card: deck.Card{Rank: 10}, slain: []deck.Card{{Rank: 3}}}},
 			c:        deck.Card{Rank: 2},
 			expected: true,
 		},
 		{
-			m:        model{weapon: weapon{card: deck.Card{Rank: 10}, slain: []deck.Card{{Rank: 3}}}},
+			m:        model{rules: official, weapon: weapon{card: deck.Card{Rank: 10}, slain: []deck.Card{{Rank: 3}}}},
 			c:        deck.Card{Rank: deck.Ace},
 			expected: false,
 		},
@@ -204,6 +214,27 @@ func TestCanUseWeapon(t *testing.T) {
 	}
 }
 
+func TestWeaponLimit(t *testing.T) {
+	tests := []struct {
+		w               weapon
+		expectedLimit   int
+		expectedLimited bool
+	}{
+		{weapon{card: deck.Card{Rank: 0}, slain: []deck.Card{}}, 0, false},
+		{weapon{card: deck.Card{Rank: 7}, slain: []deck.Card{}}, 0, false},
+		{weapon{card: deck.Card{Rank: 7}, slain: []deck.Card{{Rank: 9}}}, 9, true},
+		{weapon{card: deck.Card{Rank: 7}, slain: []deck.Card{{Rank: deck.Ace}, {Rank: deck.Queen}}}, 12, true},
+	}
+
+	for _, tt := range tests {
+		m := model{rules: official, weapon: tt.w}
+		limit, limited := m.weaponLimit()
+		if limit != tt.expectedLimit || limited != tt.expectedLimited {
+			t.Errorf("expected weapon limit to be (%d, %t), got (%d, %t)", tt.expectedLimit, tt.expectedLimited, limit, limited)
+		}
+	}
+}
+
 func TestAttackWithWeapon(t *testing.T) {
 	tests := []struct {
 		m                model
@@ -212,31 +243,31 @@ func TestAttackWithWeapon(t *testing.T) {
 		expectedSlainLen int
 	}{
 		{
-			m:                model{life: 20, weapon: weapon{card: deck.Card{Rank: 10}, slain: []deck.Card{}}},
+			m:                model{rules: official, life: 20, weapon: weapon{card: deck.Card{Rank: 10}, slain: []deck.Card{}}},
 			c:                deck.Card{Rank: 5},
 			expectedLife:     20,
 			expectedSlainLen: 1,
 		},
 		{
-			m:                model{life: 20, weapon: weapon{card: deck.Card{Rank: 10}, slain: []deck.Card{}}},
+			m:                model{rules: official, life: 20, weapon: weapon{card: deck.Card{Rank: 10}, slain: []deck.Card{}}},
 			c:                deck.Card{Rank: deck.Queen},
 			expectedLife:     18,
 			expectedSlainLen: 1,
 		},
 		{
-			m:                model{life: 20, weapon: weapon{card: deck.Card{Rank: 10}, slain: []deck.Card{}}},
+			m:                model{rules: official, life: 20, weapon: weapon{card: deck.Card{Rank: 10}, slain: []deck.Card{}}},
 			c:                deck.Card{Rank: 10},
 			expectedLife:     20,
 			expectedSlainLen: 1,
 		},
 		{
-			m:                model{life: 2, weapon: weapon{card: deck.Card{Rank: 5}, slain: []deck.Card{}}},
+			m:                model{rules: official, life: 2, weapon: weapon{card: deck.Card{Rank: 5}, slain: []deck.Card{}}},
 			c:                deck.Card{Rank: 10},
 			expectedLife:     0,
 			expectedSlainLen: 1,
 		},
 		{
-			m:                model{life: 10, weapon: weapon{card: deck.Card{Rank: 5}, slain: []deck.Card{{Rank: 4}}}},
+			m:                model{rules: official, life: 10, weapon: weapon{card: deck.Card{Rank: 5}, slain: []deck.Card{{Rank: 4}}}},
 			c:                deck.Card{Rank: 3},
 			expectedLife:     10,
 			expectedSlainLen: 2,
@@ -259,18 +290,21 @@ func TestAttackWithWeapon(t *testing.T) {
 func TestSkipRoom(t *testing.T) {
 	tests := []model{
 		{
+			rules:     official,
 			dungeon:   testDungeon(),
 			room:      testRoom(4),
 			selection: 1,
 			skippable: true,
 		},
 		{
+			rules:     official,
 			dungeon:   testDungeon(),
 			room:      testRoom(4),
 			selection: 2,
 			skippable: true,
 		},
 		{
+			rules:     official,
 			dungeon:   testDungeon(),
 			room:      testRoom(4),
 			selection: 1,
@@ -305,6 +339,7 @@ func TestDiscard(t *testing.T) {
 	}{
 		{
 			m: model{
+				rules:     official,
 				life:      20,
 				dungeon:   testDungeon(),
 				room:      testRoom(4),
@@ -320,6 +355,7 @@ func TestDiscard(t *testing.T) {
 		},
 		{
 			m: model{
+				rules:     official,
 				life:      20,
 				dungeon:   testDungeon(),
 				room:      testRoom(3),
@@ -335,6 +371,7 @@ func TestDiscard(t *testing.T) {
 		},
 		{
 			m: model{
+				rules:     official,
 				life:      20,
 				dungeon:   testDungeon(),
 				room:      testRoom(2),
@@ -367,6 +404,9 @@ func TestDiscard(t *testing.T) {
 			t.Errorf("expected viewState to be %s after discard, got %s", tt.expectedViewState, tt.m.viewState)
 		}
 		assertLastCard(t, tt.m.lastCard, tt.expectedLastCard)
+		if len(tt.m.discarded) != 1 || tt.m.discarded[0] != tt.expectedLastCard {
+			t.Errorf("expected discarded to be [%s] after discard, got %v", tt.expectedLastCard.String(), tt.m.discarded)
+		}
 		for _, c := range tt.m.room {
 			if c.Rank == selectedCard.Rank && c.Suit == selectedCard.Suit {
 				t.Errorf("expected discarded card %s to not be in room after discard", selectedCard.String())
@@ -387,6 +427,7 @@ func TestChooseAttack(t *testing.T) {
 	}{
 		{
 			m: model{
+				rules:   official,
 				life:    15,
 				dungeon: testDungeon(),
 				room: []deck.Card{
@@ -429,6 +470,67 @@ func TestChooseAttack(t *testing.T) {
 
 func TestPlayAttack(t *testing.T) {}
 
+func TestLethalConfirmation(t *testing.T) {
+	m := model{
+		rules:    official,
+		settings: settings{confirmLethal: true},
+		life:     5,
+		dungeon:  testDungeon(),
+		room: []deck.Card{
+			{Suit: deck.Club, Rank: 7},
+			{Suit: deck.Heart, Rank: 5},
+			{Suit: deck.Club, Rank: 3},
+		},
+		selection: 0,
+		viewState: viewStateRoom,
+	}
+
+	m.playRoom()
+	assertExpectedLife(t, m.life, 5)
+	assertExpectedRoomLength(t, len(m.room), 3)
+	if !m.confirming {
+		t.Error("expected lethal move to wait for confirmation")
+	}
+
+	m.down()
+	m.up()
+	if m.confirming {
+		t.Error("expected moving the selection to cancel the confirmation")
+	}
+
+	m.playRoom()
+	m.playRoom()
+	assertExpectedLife(t, m.life, 0)
+	if m.confirming {
+		t.Error("expected confirmation to be cleared after the move")
+	}
+	if m.viewState != viewStateGameOver {
+		t.Errorf("expected viewState to be %s after a lethal move, got %s", viewStateGameOver, m.viewState)
+	}
+}
+
+func TestLethalMonster(t *testing.T) {
+	tests := []struct {
+		m        model
+		c        deck.Card
+		expected bool
+	}{
+		{model{rules: official, life: 10}, deck.Card{Suit: deck.Club, Rank: 9}, false},
+		{model{rules: official, life: 10}, deck.Card{Suit: deck.Club, Rank: 10}, true},
+		{model{rules: official, life: 10}, deck.Card{Suit: deck.Heart, Rank: 10}, false},
+		{model{rules: official, life: 10, weapon: weapon{card: deck.Card{Rank: 5}}}, deck.Card{Suit: deck.Spade, Rank: deck.Ace}, false},
+		{model{rules: official, life: 10, weapon: weapon{card: deck.Card{Rank: 3}}}, deck.Card{Suit: deck.Spade, Rank: deck.Ace}, true},
+		{model{rules: official, life: 10, weapon: weapon{card: deck.Card{Rank: 5}, slain: []deck.Card{{Rank: 2}}}}, deck.Card{Suit: deck.Spade, Rank: deck.Ace}, true},
+	}
+
+	for _, tt := range tests {
+		result := tt.m.lethalMonster(tt.c)
+		if result != tt.expected {
+			t.Errorf("expected lethalMonster with card %s to be %t, got %t", tt.c.String(), tt.expected, result)
+		}
+	}
+}
+
 func TestPlayRoom(t *testing.T) {
 	tests := []struct {
 		m                  model
@@ -441,6 +543,7 @@ func TestPlayRoom(t *testing.T) {
 	}{
 		{ // Test using a potion
 			m: model{
+				rules:   official,
 				life:    15,
 				dungeon: testDungeon(),
 				room: []deck.Card{
@@ -462,6 +565,7 @@ func TestPlayRoom(t *testing.T) {
 		},
 		{ // Test equipping a weapon
 			m: model{
+				rules:   official,
 				life:    15,
 				dungeon: testDungeon(),
 				room: []deck.Card{
@@ -483,6 +587,7 @@ func TestPlayRoom(t *testing.T) {
 		},
 		{ // Test skipping a room
 			m: model{
+				rules:   official,
 				life:    15,
 				dungeon: testDungeon(),
 				room: []deck.Card{
@@ -504,6 +609,7 @@ func TestPlayRoom(t *testing.T) {
 		},
 		{ // Test attack with no weapon equipped
 			m: model{
+				rules:   official,
 				life:    15,
 				dungeon: testDungeon(),
 				room: []deck.Card{
@@ -544,6 +650,52 @@ func TestPlayRoom(t *testing.T) {
 	}
 }
 
+func TestWon(t *testing.T) {
+	tests := []struct {
+		m        model
+		expected bool
+	}{
+		{model{rules: official, life: 5, dungeon: []deck.Card{}, room: []deck.Card{}}, true},
+		{model{rules: official, life: 0, dungeon: []deck.Card{}, room: []deck.Card{}}, false},
+		{model{rules: official, life: 5, dungeon: []deck.Card{}, room: testRoom(1)}, false},
+		{model{rules: official, life: 5, dungeon: testDungeon(), room: []deck.Card{}}, false},
+	}
+
+	for _, tt := range tests {
+		if won := tt.m.won(); won != tt.expected {
+			t.Errorf("expected won with life %d, %d in the dungeon and %d in the room to be %t, got %t", tt.m.life, len(tt.m.dungeon), len(tt.m.room), tt.expected, won)
+		}
+	}
+}
+
+func TestJokerLastPotion(t *testing.T) {
+	jokers := presets["jokers"]
+	for _, use := range []jokerUse{asPotion, asWeapon, asFlee} {
+		m := model{
+			rules:     jokers,
+			life:      10,
+			room:      []deck.Card{{Suit: deck.Joker}},
+			viewState: viewStateJoker,
+		}
+		m.jokerSelection = int(use)
+		m.playJoker()
+
+		// Drunk, the joker heals and then counts again as the last potion
+		expected := 10
+		if use == asPotion {
+			expected = 10 + 2*jokers.JokerValue
+		}
+		if m.viewState != viewStateGameOver || m.score() != expected {
+			t.Errorf("expected playing the last joker as %d to end on %d, got %d (%s)", use, expected, m.score(), m.viewState)
+		}
+	}
+
+	m := model{rules: jokers, life: 10, room: []deck.Card{{Suit: deck.Joker}, {Suit: deck.Heart, Rank: 3}}}
+	if healing := m.remainingHealing(); healing != 3+jokers.JokerValue {
+		t.Errorf("expected remaining healing to count the joker as a potion, got %d", healing)
+	}
+}
+
 func TestScore(t *testing.T) {
 	tests := []struct {
 		m             model
@@ -551,7 +703,8 @@ func TestScore(t *testing.T) {
 	}{
 		{
 			m: model{
-				life: 0,
+				rules: official,
+				life:  0,
 				dungeon: []deck.Card{
 					{Suit: deck.Spade, Rank: 5},
 					{Suit: deck.Club, Rank: 7},
@@ -562,6 +715,7 @@ func TestScore(t *testing.T) {
 		},
 		{
 			m: model{
+				rules:    official,
 				life:     3,
 				dungeon:  []deck.Card{},
 				lastCard: deck.Card{Suit: deck.Spade, Rank: 9},
@@ -570,6 +724,7 @@ func TestScore(t *testing.T) {
 		},
 		{
 			m: model{
+				rules:    official,
 				life:     3,
 				dungeon:  []deck.Card{},
 				lastCard: deck.Card{Suit: deck.Heart, Rank: 9},
@@ -578,7 +733,8 @@ func TestScore(t *testing.T) {
 		},
 		{
 			m: model{
-				life: 0,
+				rules: official,
+				life:  0,
 				dungeon: []deck.Card{
 					{Suit: deck.Spade, Rank: 5},
 					{Suit: deck.Club, Rank: 7},
@@ -600,6 +756,137 @@ func TestScore(t *testing.T) {
 	}
 }
 
+func TestRemainingDamageAndHealing(t *testing.T) {
+	tests := []struct {
+		m               model
+		expectedDamage  int
+		expectedHealing int
+	}{
+		{
+			m:               model{rules: official},
+			expectedDamage:  0,
+			expectedHealing: 0,
+		},
+		{
+			m: model{
+				rules:   official,
+				dungeon: testDungeon(),
+				room:    testRoom(4),
+			},
+			expectedDamage:  2 + 3 + 4 + 5 + 6 + 7 + 8 + 9 + 6 + 7,
+			expectedHealing: 8,
+		},
+		{
+			m: model{
+				rules: official,
+				dungeon: []deck.Card{
+					{Suit: deck.Club, Rank: deck.Ace},
+					{Suit: deck.Heart, Rank: 10},
+				},
+				room: []deck.Card{
+					{Suit: deck.Spade, Rank: deck.King},
+					{Suit: deck.Heart, Rank: 2},
+					{Suit: deck.Diamond, Rank: 5},
+				},
+			},
+			expectedDamage:  14 + 13,
+			expectedHealing: 12,
+		},
+	}
+
+	for _, tt := range tests {
+		if damage := tt.m.remainingDamage(); damage != tt.expectedDamage {
+			t.Errorf("expected remaining damage to be %d, got %d", tt.expectedDamage, damage)
+		}
+		if healing := tt.m.remainingHealing(); healing != tt.expectedHealing {
+			t.Errorf("expected remaining healing to be %d, got %d", tt.expectedHealing, healing)
+		}
+	}
+}
+
+// The counter counts the same cards the damage and healing left come from
+func TestCounterView(t *testing.T) {
+	m := model{
+		rules:    official,
+		life:     20,
+		settings: settings{ascii: true},
+		dungeon: []deck.Card{
+			{Suit: deck.Club, Rank: deck.Ace},
+			{Suit: deck.Heart, Rank: 10},
+		},
+		room: []deck.Card{
+			{Suit: deck.Spade, Rank: deck.King},
+			{Suit: deck.Heart, Rank: 2},
+		},
+	}
+
+	view := m.counterView()
+	for _, expected := range []string{"M 14 13", "+ 10 2", "Damage left: 27", "Life + healing: 20 + 12"} {
+		if !strings.Contains(view, expected) {
+			t.Errorf("expected the counter to contain %q, got\n%s", expected, view)
+		}
+	}
+}
+
+func TestMouseTarget(t *testing.T) {
+	m := model{
+		rules:     official,
+		life:      20,
+		dungeon:   testDungeon(),
+		room:      testRoom(4),
+		skippable: true,
+		viewState: viewStateRoom,
+		width:     80,
+		height:    24,
+	}
+
+	tests := []struct {
+		x, y     int
+		expected int
+	}{
+		{30, 8, 0},
+		{31, 11, 3},
+		{30, 12, -1},
+		{32, 13, 4},
+		{10, 8, -1},
+		{30, 2, -1},
+	}
+
+	for _, tt := range tests {
+		target := m.mouseTarget(tt.x, tt.y)
+		if target != tt.expected {
+			t.Errorf("expected mouse target at (%d, %d) to be %d, got %d", tt.x, tt.y, tt.expected, target)
+		}
+	}
+}
+
+// The counter beside the room mustn't move the lines the mouse picks from
+func TestMouseTargetWithCounter(t *testing.T) {
+	for _, height := range []int{14, 16, 24} {
+		m := newGame(1, presets["jokers"], settings{ascii: true})
+		m.showCounter = true
+		m.width, m.height = 100, height
+
+		rows := strings.Split(ansi.Strip(m.View()), "\n")
+		lines, targets := m.roomLines()
+		row := 0
+		for i, line := range lines {
+			text := strings.TrimSpace(ansi.Strip(line))
+			for row < len(rows) && !strings.Contains(rows[row], text) {
+				row++
+			}
+			if row == len(rows) {
+				t.Fatalf("expected %q on screen at height %d, got\n%s", text, height, strings.Join(rows, "\n"))
+			}
+			x := strings.Index(rows[row], text)
+			if got := m.mouseTarget(x, row); got != targets[i] {
+				t.Errorf("expected %q at (%d, %d) with height %d to be %d, got %d", text, x, row, height, targets[i], got)
+			}
+			row++
+		}
+	}
+}
+
 func assertExpectedLife(t testing.TB, got, expected int) {
 	t.Helper()
 	if got != expected {
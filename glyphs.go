@@ -0,0 +1,166 @@
+package main
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+// glyphs are the symbols the screens are drawn with, so they can be swapped
+// for plain ASCII on terminals and fonts without emoji
+type glyphs struct {
+	life     string
+	potion   string
+	weapon   string
+	monster  string
+	joker    string
+	fists    string
+	faceDown string
+	solved   string
+
+	menuTitle     string
+	seedTitle     string
+	statsTitle    string
+	puzzlesTitle  string
+	settingsTitle string
+	gameOver      string
+	victory       string
+
+	// weapon stack card edges
+	cardTop    string
+	cardSide   string
+	cardBottom string
+	cardEnds   [3]string
+
+	spades   string
+	hearts   string
+	diamonds string
+	clubs    string
+
+	sparks []rune
+
+	// life going from one number to another, how many of a strength there
+	// are, and the penalty taken off a score
+	arrow string
+	times string
+	minus string
+
+	// the end of text being typed in
+	cursor string
+
+	// the card counter's frame
+	border lipgloss.Border
+}
+
+var emojiGlyphs = glyphs{
+	life:     "❤️",
+	potion:   "❤️",
+	weapon:   "🗡️",
+	monster:  "🐍",
+	joker:    "🃏",
+	fists:    "👊",
+	faceDown: deck.CardBack,
+	solved:   "✓",
+
+	menuTitle:     "🐍 Scoundrel 🗡️",
+	seedTitle:     "🌱 Seeded Game 🌱",
+	statsTitle:    "📜 Statistics 📜",
+	puzzlesTitle:  "🧩 Puzzles 🧩",
+	settingsTitle: "⚙️ Settings ⚙️",
+	gameOver:      "💀 Game Over 💀",
+	victory:       "🏆 Victory 🏆",
+
+	cardTop:    "╭───",
+	cardSide:   "│",
+	cardBottom: "╰───",
+	cardEnds:   [3]string{"╮", "│", "╯"},
+
+	spades:   deck.Spade.Symbol(),
+	hearts:   deck.Heart.Symbol(),
+	diamonds: deck.Diamond.Symbol(),
+	clubs:    deck.Club.Symbol(),
+
+	sparks: []rune("▁▂▃▄▅▆▇█"),
+
+	arrow:  "→",
+	times:  "×",
+	minus:  "−",
+	cursor: "█",
+	border: lipgloss.RoundedBorder(),
+}
+
+var asciiGlyphs = glyphs{
+	life:     "HP",
+	potion:   "+",
+	weapon:   "/",
+	monster:  "M",
+	joker:    "*",
+	fists:    "fists",
+	faceDown: "##",
+	solved:   "x",
+
+	menuTitle:     "SCOUNDREL",
+	seedTitle:     "SEEDED GAME",
+	statsTitle:    "STATISTICS",
+	puzzlesTitle:  "PUZZLES",
+	settingsTitle: "SETTINGS",
+	gameOver:      "GAME OVER",
+	victory:       "VICTORY",
+
+	cardTop:    "+---",
+	cardSide:   "|",
+	cardBottom: "+---",
+	cardEnds:   [3]string{"+", "|", "+"},
+
+	spades:   "S",
+	hearts:   "H",
+	diamonds: "D",
+	clubs:    "C",
+
+	sparks: []rune("_.-=+*#@"),
+
+	arrow:  "->",
+	times:  "x",
+	minus:  "-",
+	cursor: "_",
+	border: lipgloss.ASCIIBorder(),
+}
+
+func (s settings) glyphs() glyphs {
+	if s.ascii {
+		return asciiGlyphs
+	}
+	return emojiGlyphs
+}
+
+// suit returns the symbol for a card's suit
+func (g glyphs) suit(s deck.Suit) string {
+	switch s {
+	case deck.Spade:
+		return g.spades
+	case deck.Heart:
+		return g.hearts
+	case deck.Diamond:
+		return g.diamonds
+	case deck.Club:
+		return g.clubs
+	case deck.Joker:
+		return g.joker
+	default:
+		return "?"
+	}
+}
+
+// card returns the symbol for what a card is in the dungeon
+func (g glyphs) card(c deck.Card) string {
+	switch c.Suit {
+	case deck.Heart:
+		return g.potion
+	case deck.Diamond:
+		return g.weapon
+	case deck.Joker:
+		return g.joker
+	default:
+		return g.monster
+	}
+}
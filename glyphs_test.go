@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+// screens returns every screen, and the game in each of its views
+func screens(t *testing.T, s settings) map[string]tea.Model {
+	t.Helper()
+	r := s.rules()
+	if err := recordGame(s.dataDir, r, 12, true); err != nil {
+		t.Fatal(err)
+	}
+
+	seed := newSeedInput(s)
+	seed.input = "123"
+
+	puzzles, err := loadPuzzles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := recordPuzzle(s.dataDir, puzzles[0].name); err != nil {
+		t.Fatal(err)
+	}
+	solved, err := loadSolved(s.dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	room := newGame(1, r, s)
+	room.showCounter = true
+	room.weapon = weapon{card: deck.Card{Suit: deck.Diamond, Rank: 5}, slain: deck.Pile{{Suit: deck.Spade, Rank: 3}}}
+
+	attack := room
+	attack.room = deck.Pile{{Suit: deck.Club, Rank: 2}, {Suit: deck.Spade, Rank: 9}}
+	attack.viewState = viewStateAttack
+
+	joker := newGame(1, presets["jokers"], s)
+	joker.room = deck.Pile{{Suit: deck.Joker}, {Suit: deck.Club, Rank: 2}}
+	joker.viewState = viewStateJoker
+
+	relic := newCampaign(1, r, s)
+	relic.offerRelics()
+
+	lost := room
+	lost.life = 0
+	lost.viewState = viewStateGameOver
+	lost.err = errors.New("disk full")
+
+	won := newGame(1, r, s)
+	won.dungeon, won.room = nil, nil
+	won.viewState = viewStateGameOver
+
+	return map[string]tea.Model{
+		"menu":     newMenu(s),
+		"seed":     seed,
+		"settings": newSettingsScreen(s),
+		"stats":    showStats(s)().(switchScreenMsg).screen,
+		"puzzles":  puzzlesModel{settings: s, puzzles: puzzles, solved: solved},
+		"room":     room,
+		"attack":   attack,
+		"joker":    joker,
+		"relic":    relic,
+		"lost":     lost,
+		"won":      won,
+	}
+}
+
+func TestASCIIScreens(t *testing.T) {
+	s := defaultSettings()
+	s.ascii = true
+	s.dataDir = t.TempDir()
+
+	for name, screen := range screens(t, s) {
+		screen, _ = screen.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+		view := screen.View()
+		for i := 0; i < len(view); i++ {
+			if view[i] >= 0x80 {
+				t.Errorf("expected the %s screen in plain ASCII, got %q in\n%s", name, view[i], view)
+				break
+			}
+		}
+	}
+}
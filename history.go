@@ -0,0 +1,96 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+type eventKind string
+
+const (
+	eventPotion  eventKind = "potion"
+	eventEquip   eventKind = "equip"
+	eventFists   eventKind = "fists"
+	eventWeapon  eventKind = "weapon"
+	eventSkip    eventKind = "skip"
+	eventFlee    eventKind = "flee"
+	eventDescend eventKind = "descend"
+
+	// A relic healing, and a relic taken or, with an empty Relic, passed up
+	eventRelic     eventKind = "relic"
+	eventTakeRelic eventKind = "take relic"
+	eventCleared   eventKind = "cleared"
+)
+
+// event is a single move of the run along with the life before and after
+// it. The fields are exported so the history is kept in saved games.
+type event struct {
+	Kind   eventKind
+	Card   deck.Card
+	Before int
+	After  int
+
+	// The relic behind the event, if any
+	Relic string
+}
+
+func (m *model) log(kind eventKind, c deck.Card, before int) {
+	m.events = append(m.events, event{Kind: kind, Card: c, Before: before, After: m.life})
+}
+
+// runSummary is what the game over screen reports about a run
+type runSummary struct {
+	roomsCleared  int
+	roomsSkipped  int
+	potionsDrunk  int
+	healingWasted int
+	weaponKills   int
+	fistKills     int
+	biggestHit    int
+
+	// life over the run, starting before the first move
+	life []int
+}
+
+func (m model) summary() runSummary {
+	var s runSummary
+	for i, e := range m.events {
+		if i == 0 {
+			s.life = append(s.life, e.Before)
+		}
+
+		switch e.Kind {
+		case eventPotion:
+			s.potionsDrunk++
+			s.healingWasted += max(0, m.rules.value(e.Card)-(e.After-e.Before))
+		case eventFists:
+			s.fistKills++
+		case eventWeapon:
+			s.weaponKills++
+		case eventSkip, eventFlee:
+			s.roomsSkipped++
+		case eventCleared:
+			s.roomsCleared++
+		}
+
+		s.biggestHit = max(s.biggestHit, e.Before-e.After)
+		if e.After != e.Before {
+			s.life = append(s.life, e.After)
+		}
+	}
+	return s
+}
+
+// sparkline draws the values as a line of bars scaled so top is the tallest
+func sparkline(values []int, top int, sparks []rune) string {
+	var b strings.Builder
+	for _, v := range values {
+		i := 0
+		if top > 0 {
+			i = min(max(0, v), top) * (len(sparks) - 1) / top
+		}
+		b.WriteRune(sparks[i])
+	}
+	return b.String()
+}
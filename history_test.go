@@ -0,0 +1,103 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+func TestSummary(t *testing.T) {
+	m := model{rules: official, life: 20}
+	m.attackWithFists(deck.Card{Suit: deck.Club, Rank: 9})
+	m.usePotion(deck.Card{Suit: deck.Heart, Rank: 10})
+	m.equipWeapon(deck.Card{Suit: deck.Diamond, Rank: 5})
+	m.attackWithWeapon(deck.Card{Suit: deck.Spade, Rank: deck.Queen})
+	m.attackWithWeapon(deck.Card{Suit: deck.Spade, Rank: 4})
+	m.log(eventCleared, deck.Card{}, m.life)
+	m.skipRoom()
+
+	s := m.summary()
+	expected := runSummary{
+		roomsCleared:  1,
+		roomsSkipped:  1,
+		potionsDrunk:  1,
+		healingWasted: 1,
+		weaponKills:   2,
+		fistKills:     1,
+		biggestHit:    9,
+	}
+	if s.roomsCleared != expected.roomsCleared || s.roomsSkipped != expected.roomsSkipped ||
+		s.potionsDrunk != expected.potionsDrunk || s.healingWasted != expected.healingWasted ||
+		s.weaponKills != expected.weaponKills || s.fistKills != expected.fistKills ||
+		s.biggestHit != expected.biggestHit {
+		t.Errorf("expected summary to be %+v, got %+v", expected, s)
+	}
+
+	expectedLife := []int{20, 11, 20, 13}
+	if len(s.life) != len(expectedLife) {
+		t.Fatalf("expected life to be %v, got %v", expectedLife, s.life)
+	}
+	for i := range expectedLife {
+		if s.life[i] != expectedLife[i] {
+			t.Errorf("expected life to be %v, got %v", expectedLife, s.life)
+			break
+		}
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		values   []int
+		top      int
+		expected string
+	}{
+		{[]int{}, 20, ""},
+		{[]int{20, 10, 0}, 20, "█▄▁"},
+		{[]int{25, -3}, 20, "█▁"},
+		{[]int{1, 2}, 0, "▁▁"},
+	}
+
+	for _, tt := range tests {
+		if s := sparkline(tt.values, tt.top, emojiGlyphs.sparks); s != tt.expected {
+			t.Errorf("expected sparkline of %v to be %q, got %q", tt.values, tt.expected, s)
+		}
+	}
+}
+
+func TestRoomsClearedWholeGame(t *testing.T) {
+	for _, variant := range []string{"official", "house", "easy"} {
+		for seed := range int64(20) {
+			s := settings{variant: variant}
+			m := newGame(seed, s.rules(), s)
+
+			// The first room, and every refill the player lived to see
+			dealt, skips := 1, 0
+			for m.viewState != viewStateGameOver {
+				before, events := len(m.dungeon), len(m.events)
+				if err := m.play(botMove(m)); err != nil {
+					t.Fatal(err)
+				}
+				skipped := false
+				for _, e := range m.events[events:] {
+					skipped = skipped || e.Kind == eventSkip || e.Kind == eventFlee
+				}
+				switch {
+				case skipped:
+					dealt++
+					skips++
+				case len(m.dungeon) < before && m.life > 0:
+					dealt++
+				}
+			}
+
+			// The room the player died in wasn't cleared
+			expected := dealt - skips
+			if !m.won() {
+				expected--
+			}
+			if got := m.summary().roomsCleared; got != expected {
+				t.Errorf("expected %s seed %d to clear %d rooms, got %d", variant, seed, expected, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+// jokerUse is how a joker is played, in the order the choices are listed
+type jokerUse int
+
+const (
+	asPotion jokerUse = iota
+	asWeapon
+	asFlee
+	jokerCancel
+)
+
+func isJoker(c deck.Card) bool {
+	return c.Suit == deck.Joker
+}
+
+// chooseJoker asks how to play the selected joker
+func (m *model) chooseJoker() {
+	m.viewState = viewStateJoker
+	m.jokerSelection = int(asPotion)
+}
+
+// playJoker plays the selected joker the chosen way
+func (m *model) playJoker() {
+	c := m.room[m.selection]
+	switch jokerUse(m.jokerSelection) {
+	case asPotion:
+		m.usePotion(c)
+	case asWeapon:
+		m.equipWeapon(c)
+	case asFlee:
+		m.flee()
+		return
+	default:
+		m.viewState = viewStateRoom
+		return
+	}
+	m.discard()
+}
+
+// flee discards the joker and escapes the room, putting the rest of it at
+// the bottom of the dungeon. It works even after a skip, but the room fled
+// to can't be skipped.
+func (m *model) flee() {
+	m.lastCard = m.room.Remove(m.selection)
+	m.discarded.PutBottom(m.lastCard)
+	m.log(eventFlee, m.lastCard, m.life)
+
+	m.dungeon.PutBottom(m.room.Draw(m.room.Len())...)
+	m.drawToRoom(m.rules.RoomSize)
+	m.roomDrawn()
+	m.viewState = viewStateRoom
+	m.selection = 0
+	m.jokerSelection = 0
+	m.confirming = false
+	m.skippable = false
+
+	m.gameOverCheck()
+}
+
+func (m model) chooseJokerView() string {
+	header := m.headerView()
+	footer := m.footerView()
+	selectionLines, _ := m.jokerLines()
+
+	return layoutView(header, selectionLines, footer, m.layoutWidth(), m.height)
+}
+
+// jokerLines returns the selection lines of the joker view along with the
+// use each line maps to, or -1 for spacing lines
+func (m model) jokerLines() ([]string, []int) {
+	cursor := map[bool]string{true: ">", false: " "}
+	g := m.settings.glyphs()
+	value := m.rules.JokerValue
+
+	var selectionLines []string
+	selectionLines = append(selectionLines, fmt.Sprintf("%s Drink as a %s %d", cursor[m.jokerSelection == int(asPotion)], g.potion, value))
+	selectionLines = append(selectionLines, fmt.Sprintf("%s Wield as a %s %d", cursor[m.jokerSelection == int(asWeapon)], g.weapon, value))
+	selectionLines = append(selectionLines, fmt.Sprintf("%s Flee the room", cursor[m.jokerSelection == int(asFlee)]))
+	selectionLines = append(selectionLines, "")
+	selectionLines = append(selectionLines, fmt.Sprintf("%s Cancel", cursor[m.jokerSelection == int(jokerCancel)]))
+
+	return selectionLines, []int{int(asPotion), int(asWeapon), int(asFlee), -1, int(jokerCancel)}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+func jokerRoom() model {
+	return model{
+		rules:   presets["jokers"],
+		life:    10,
+		dungeon: testDungeon(),
+		room: []deck.Card{
+			{Suit: deck.Joker, Rank: 0},
+			{Suit: deck.Spade, Rank: 9},
+			{Suit: deck.Heart, Rank: 3},
+			{Suit: deck.Club, Rank: 4},
+		},
+		viewState: viewStateRoom,
+	}
+}
+
+func TestNewDungeonWithJokers(t *testing.T) {
+	d := newDungeon(1, presets["jokers"])
+	assertExpectedDungeonLength(t, len(d), 52-8+2)
+
+	jokers := 0
+	for _, c := range d {
+		if isJoker(c) {
+			jokers++
+		}
+	}
+	if jokers != 2 {
+		t.Errorf("expected 2 jokers, got %d", jokers)
+	}
+}
+
+func TestPlayJoker(t *testing.T) {
+	tests := []struct {
+		use          jokerUse
+		expectedLife int
+		expectedView viewState
+	}{
+		{asPotion, 16, viewStateRoom},
+		{asWeapon, 10, viewStateRoom},
+		{jokerCancel, 10, viewStateRoom},
+	}
+
+	for _, test := range tests {
+		m := jokerRoom()
+		m.playRoom()
+		if m.viewState != viewStateJoker {
+			t.Fatalf("expected playing a joker to ask how, got view %s", m.viewState)
+		}
+
+		m.jokerSelection = int(test.use)
+		m.playJoker()
+		assertExpectedLife(t, m.life, test.expectedLife)
+		if m.viewState != test.expectedView {
+			t.Errorf("expected view to be %s, got %s", test.expectedView, m.viewState)
+		}
+	}
+
+	m := jokerRoom()
+	m.selection = 0
+	m.jokerSelection = int(asWeapon)
+	m.playJoker()
+	if m.weaponPower() != 6 {
+		t.Errorf("expected weapon power to be 6, got %d", m.weaponPower())
+	}
+	if m.weaponDamage(deck.Card{Suit: deck.Spade, Rank: 9}) != 3 {
+		t.Errorf("expected weapon damage to be 3, got %d", m.weaponDamage(deck.Card{Suit: deck.Spade, Rank: 9}))
+	}
+}
+
+func TestFlee(t *testing.T) {
+	m := jokerRoom()
+	m.skippable = false
+	dungeon := len(m.dungeon)
+
+	m.selection = 0
+	m.jokerSelection = int(asFlee)
+	m.playJoker()
+
+	if len(m.room) != 4 {
+		t.Errorf("expected room to have 4 cards, got %d", len(m.room))
+	}
+	if len(m.dungeon) != dungeon+3-4 {
+		t.Errorf("expected dungeon to have %d cards, got %d", dungeon+3-4, len(m.dungeon))
+	}
+	if m.skippable {
+		t.Errorf("expected the room fled to not be skippable")
+	}
+	if m.lastCard.Suit != deck.Joker {
+		t.Errorf("expected the joker to be discarded, got %s", m.lastCard)
+	}
+	if e := m.events[len(m.events)-1]; e.Kind != eventFlee {
+		t.Errorf("expected a flee event, got %s", e.Kind)
+	}
+}
+
+func TestJokerCommands(t *testing.T) {
+	m := jokerRoom()
+	if err := m.command("play 1"); err == nil {
+		t.Errorf("expected playing a joker without saying how to fail")
+	}
+	if err := m.command("play 1 potion"); err != nil {
+		t.Fatal(err)
+	}
+	assertExpectedLife(t, m.life, 16)
+
+	// The moves the bot considers replay the same
+	m = jokerRoom()
+	for _, mv := range m.legalMoves() {
+		if !isJoker(mv.card) {
+			continue
+		}
+		next := m.clone()
+		if err := next.play(mv); err != nil {
+			t.Errorf("expected %s to play, got %v", m.commandFor(mv), err)
+			continue
+		}
+		replayed, ok := moveFor(next.events[0])
+		if !ok || replayed != mv {
+			t.Errorf("expected event to replay as %+v, got %+v", mv, replayed)
+		}
+	}
+}
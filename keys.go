@@ -0,0 +1,72 @@
+package main
+
+import (
+	"sort"
+)
+
+// Actions that keys can be bound to. ctrl+c always quits and can't be
+// rebound.
+const (
+	keyUp      = "up"
+	keyDown    = "down"
+	keyChoose  = "choose"
+	keyBack    = "back"
+	keyCounter = "counter"
+)
+
+// keymap binds each action to the keys that trigger it
+type keymap map[string][]string
+
+func defaultKeymap() keymap {
+	return keymap{
+		keyUp:      {"up", "k"},
+		keyDown:    {"down", "j"},
+		keyChoose:  {"enter"},
+		keyBack:    {"q", "esc"},
+		keyCounter: {"c"},
+	}
+}
+
+// action returns the action a key is bound to, or "" if it isn't bound. A
+// nil keymap uses the default bindings.
+func (k keymap) action(key string) string {
+	if k == nil {
+		k = defaultKeymap()
+	}
+	if key == "ctrl+c" {
+		return ""
+	}
+	for _, name := range k.actions() {
+		for _, bound := range k[name] {
+			if bound == key {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// clash finds a key bound to two actions, which action would quietly take
+// from the other
+func (k keymap) clash() (key string, first string, second string, found bool) {
+	bound := map[string]string{}
+	for _, name := range k.actions() {
+		for _, key := range k[name] {
+			if other, ok := bound[key]; ok && other != name {
+				return key, other, name, true
+			}
+			bound[key] = name
+		}
+	}
+	return "", "", "", false
+}
+
+// actions lists the bound actions in a stable order
+func (k keymap) actions() []string {
+	var names []string
+	for name := range k {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
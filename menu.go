@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// menuModel is the title screen
+type menuModel struct {
+	settings  settings
+	items     []menuItem
+	selection int
+	err       error
+
+	// Terminal dimensions
+	width  int
+	height int
+}
+
+type menuItem struct {
+	label    string
+	disabled bool
+	choose   func(s settings) tea.Cmd
+}
+
+// menuErrMsg reports a menu action that failed
+type menuErrMsg struct {
+	err error
+}
+
+func newMenu(s settings) menuModel {
+	return menuModel{
+		settings: s,
+		items: []menuItem{
+			{label: "New Game", choose: func(s settings) tea.Cmd {
+				return switchTo(newGame(newSeed(), s.rules(), s))
+			}},
+			{label: "Continue", disabled: !hasSave(s.dataDir), choose: continueGame},
+			{label: "Daily Dungeon", choose: func(s settings) tea.Cmd {
+				return switchTo(newGame(dailySeed(time.Now()), s.rules(), s))
+			}},
+			{label: "Campaign", choose: func(s settings) tea.Cmd {
+				return switchTo(newCampaign(newSeed(), s.rules(), s))
+			}},
+			{label: "Puzzles", choose: showPuzzles},
+			{label: "Seeded Game", choose: func(s settings) tea.Cmd {
+				return switchTo(newSeedInput(s))
+			}},
+			{label: "Statistics", choose: showStats},
+			{label: "Settings", choose: func(s settings) tea.Cmd {
+				return switchTo(newSettingsScreen(s))
+			}},
+			{label: "Quit", choose: func(s settings) tea.Cmd {
+				return tea.Quit
+			}},
+		},
+	}
+}
+
+func continueGame(s settings) tea.Cmd {
+	return func() tea.Msg {
+		m, err := loadGame(s.dataDir, s)
+		if err != nil {
+			return menuErrMsg{err}
+		}
+		return switchScreenMsg{m}
+	}
+}
+
+func newSeed() int64 {
+	return time.Now().UnixNano()
+}
+
+// dailySeed gives everyone the same dungeon on the same day
+func dailySeed(t time.Time) int64 {
+	return int64(t.Year()*10000 + int(t.Month())*100 + t.Day())
+}
+
+func (m menuModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m menuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case menuErrMsg:
+		m.err = msg.err
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+
+		switch m.settings.keys.action(msg.String()) {
+		case keyBack:
+			return m, tea.Quit
+		case keyUp:
+			m.move(-1)
+		case keyDown:
+			m.move(1)
+		case keyChoose:
+			m.err = nil
+			return m, m.items[m.selection].choose(m.settings)
+		}
+	}
+
+	return m, nil
+}
+
+// move steps the selection over any disabled items
+func (m *menuModel) move(step int) {
+	for range m.items {
+		m.selection = (m.selection + step + len(m.items)) % len(m.items)
+		if !m.items[m.selection].disabled {
+			return
+		}
+	}
+}
+
+func (m menuModel) View() string {
+	s := m.settings.glyphs().menuTitle + "\n\n"
+	for i, item := range m.items {
+		cursor := " "
+		if m.selection == i {
+			cursor = ">"
+		}
+		label := item.label
+		if item.disabled {
+			label = m.settings.styles().disabled.Render(label)
+		}
+		s += fmt.Sprintf("%s %s\n", cursor, label)
+	}
+	if m.err != nil {
+		s += "\n" + m.settings.styles().danger.Render(m.err.Error()) + "\n"
+	}
+	s += "\nPress enter to choose. Press q to quit."
+
+	return placeView(s, m.width, m.height)
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+type model struct {
+	seed      int64
+	dungeon   deck.Pile
+	room      deck.Pile
+	discarded deck.Pile
+	life      int
+	weapon    weapon
+	skippable bool
+	lastCard  deck.Card
+	events    []event
+	rules     Rules
+	campaign  campaign
+
+	// The potion drunk last, for the bonus if it's the last card played
+	lastPotion deck.Card
+
+	// Relics held, those on offer between dungeons, and the potions drunk
+	// in the current room for relics that count them
+	relics      []string
+	relicOffers []string
+	roomPotions int
+
+	// The puzzle being played, if any
+	puzzle *puzzle
+
+	selection           int
+	attackTypeSelection int
+	jokerSelection      int
+	relicSelection      int
+	viewState           viewState
+	showCounter         bool
+	confirming          bool
+	settings            settings
+	err                 error
+	announcement        string
+
+	// Animation state, see animation.go
+	shownLife int
+	flash     int
+	dealt     int
+	ticking   bool
+
+	// Terminal dimensions
+	width  int
+	height int
+}
+
+type weapon struct {
+	card  deck.Card
+	slain deck.Pile
+}
+
+// equipped reports whether there's a weapon at all
+func (w weapon) equipped() bool {
+	return w.card != deck.Card{}
+}
+
+func newGame(seed int64, r Rules, s settings) model {
+	m := model{
+		seed:      seed,
+		rules:     r,
+		dungeon:   newDungeon(seed, r),
+		room:      deck.Pile{},
+		discarded: deck.Pile{},
+		life:      r.StartLife,
+		weapon: weapon{
+			card:  deck.Card{Rank: 0},
+			slain: deck.Pile{},
+		},
+		skippable: true,
+
+		selection:           0,
+		attackTypeSelection: 1,
+		viewState:           viewStateRoom,
+		showCounter:         s.showCounter,
+		settings:            s,
+	}
+
+	m.drawToRoom(r.RoomSize)
+	m.shownLife = m.life
+
+	return m
+}
+
+// gameRecordedMsg is sent once a finished game has been added to the
+// statistics
+type gameRecordedMsg struct {
+	err error
+}
+
+func (m model) Init() tea.Cmd {
+	if m.settings.accessible {
+		return tea.Println(m.describe() + "\n" + accessibleKeys)
+	}
+
+	// Init can't keep the ticking flag, so the first step sets it
+	if m.animating() {
+		return tick()
+	}
+	return nil
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.settings.accessible {
+		prev := m
+		next, cmd := m.update(msg)
+		g := next.(model)
+		if s := g.narrate(prev); s != "" {
+			cmd = tea.Batch(cmd, tea.Println(s))
+		}
+		return g, cmd
+	}
+	return m.update(msg)
+}
+
+func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	wasOver := m.viewState == viewStateGameOver
+	life := m.life
+	events := len(m.events)
+
+	switch msg := msg.(type) {
+
+	// Handle window size changes
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case gameRecordedMsg:
+		m.err = msg.err
+
+	case tickMsg:
+		return m, m.step()
+
+	// Clicks and hovering over the selection lines
+	case tea.MouseMsg:
+		m.mouse(msg)
+
+	// Is it a key press?
+	case tea.KeyMsg:
+
+		// This key should exit the program.
+		if msg.String() == "ctrl+c" {
+			return m, m.leave(true)
+		}
+
+		// Cool, what was the key pressed bound to?
+		switch m.settings.keys.action(msg.String()) {
+
+		// These keys put the game aside and go back to the menu.
+		case keyBack:
+			return m, m.leave(false)
+
+		case keyUp:
+			m.up()
+		case keyDown:
+			m.down()
+		case keyCounter:
+			m.showCounter = !m.showCounter
+		case keyChoose:
+			// Handle selection based on current view state
+			switch m.viewState {
+			case viewStateAttack:
+				m.playAttack()
+			case viewStateJoker:
+				m.playJoker()
+			case viewStateRelic:
+				m.takeRelic()
+			case viewStateRoom:
+				m.playRoom()
+			case viewStateGameOver:
+				m = m.playAgain()
+			}
+		}
+	}
+
+	if m.life < life {
+		m.flash = flashFrames
+	}
+	if len(m.events) > events {
+		m.announcement = m.announce(m.events[events:])
+	}
+	cmd := m.animate()
+
+	if !wasOver && m.viewState == viewStateGameOver {
+		return m, tea.Batch(cmd, m.record())
+	}
+	return m, cmd
+}
+
+// playAgain starts a fresh game, or campaign, or the same puzzle again, with
+// the same settings and terminal size
+func (m model) playAgain() model {
+	var g model
+	switch {
+	case m.inPuzzle():
+		g = newPuzzle(*m.puzzle, m.settings)
+	case m.inCampaign():
+		g = newCampaign(newSeed(), m.rules, m.settings)
+	default:
+		g = newGame(newSeed(), m.rules, m.settings)
+	}
+	g.width = m.width
+	g.height = m.height
+	g.ticking = m.ticking
+	return g
+}
+
+// leave saves an unfinished game so it can be continued from the menu, then
+// either quits or goes back to the menu
+func (m model) leave(quit bool) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		if m.viewState != viewStateGameOver {
+			err = saveGame(m.settings.dataDir, m)
+		}
+		// The menu is drawn, so accessible mode doesn't go back to it
+		if quit || m.settings.accessible {
+			return tea.QuitMsg{}
+		}
+
+		menu := newMenu(m.settings)
+		menu.err = err
+		return switchScreenMsg{menu}
+	}
+}
+
+// record adds a finished game to the statistics and clears its save. The
+// save is cleared last, so failing to clear it doesn't lose the game.
+func (m model) record() tea.Cmd {
+	return func() tea.Msg {
+		err := m.recordResult()
+		return gameRecordedMsg{errors.Join(err, deleteSave(m.settings.dataDir))}
+	}
+}
+
+// recordResult writes the replay and adds the game to the statistics, the
+// campaign leaderboard or the solved puzzles
+func (m model) recordResult() error {
+	// Puzzles are dealt by hand, so there's no seed to replay them from and
+	// their scores aren't comparable
+	if m.inPuzzle() {
+		if !m.solved() {
+			return nil
+		}
+		return recordPuzzle(m.settings.dataDir, m.puzzle.name)
+	}
+
+	replayErr := saveReplay(filepath.Join(m.settings.dataDir, replayFile), newReplay(m))
+	if m.inCampaign() {
+		return errors.Join(replayErr, recordCampaign(m.settings.dataDir, newCampaignEntry(m, time.Now())))
+	}
+	return errors.Join(replayErr, recordGame(m.settings.dataDir, m.rules, m.score(), m.won()))
+}
+
+func (m model) View() string {
+	// Accessible mode prints as it goes instead
+	if m.settings.accessible {
+		return ""
+	}
+
+	switch m.viewState {
+	case viewStateRoom:
+		return m.withCounter(m.roomView())
+	case viewStateAttack:
+		return m.withCounter(m.chooseAttackView())
+	case viewStateJoker:
+		return m.withCounter(m.chooseJokerView())
+	case viewStateRelic:
+		return m.withCounter(m.chooseRelicView())
+	case viewStateGameOver:
+		return centerView(m.gameOverView(), m.width, m.height)
+	default:
+		return "Unknown view state"
+	}
+}
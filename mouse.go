@@ -0,0 +1,78 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// mouseTarget returns the selection under the mouse in the current view, or
+// -1 if the mouse isn't over a selectable line
+func (m model) mouseTarget(x, y int) int {
+	var lines []string
+	var targets []int
+	switch m.viewState {
+	case viewStateRoom:
+		lines, targets = m.roomLines()
+	case viewStateAttack:
+		lines, targets = m.chooseAttackLines()
+	case viewStateJoker:
+		lines, targets = m.jokerLines()
+	case viewStateRelic:
+		lines, targets = m.relicLines()
+	default:
+		return -1
+	}
+
+	i := selectionAt(m.headerView(), lines, m.footerView(), m.layoutWidth(), m.height, x, y)
+	if i < 0 {
+		return -1
+	}
+	return targets[i]
+}
+
+// mouse moves the selection to whatever is under the cursor and plays it on
+// a left click
+func (m *model) mouse(msg tea.MouseMsg) {
+	if m.viewState == viewStateGameOver {
+		if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
+			*m = m.playAgain()
+		}
+		return
+	}
+
+	target := m.mouseTarget(msg.X, msg.Y)
+	if target < 0 {
+		return
+	}
+
+	switch m.viewState {
+	case viewStateRoom:
+		if m.selection != target {
+			m.confirming = false
+		}
+		m.selection = target
+	case viewStateAttack:
+		if m.attackTypeSelection != target {
+			m.confirming = false
+		}
+		m.attackTypeSelection = target
+	case viewStateJoker:
+		m.jokerSelection = target
+	case viewStateRelic:
+		m.relicSelection = target
+	}
+
+	if msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft {
+		return
+	}
+
+	switch m.viewState {
+	case viewStateRoom:
+		m.playRoom()
+	case viewStateAttack:
+		m.playAttack()
+	case viewStateJoker:
+		m.playJoker()
+	case viewStateRelic:
+		m.takeRelic()
+	}
+}
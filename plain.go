@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const plainHelp = `Commands:
+  play N               play card N of the room
+  play N potion|weapon|flee
+                       play joker N as a potion, a weapon or to flee
+  fight N fists        fight monster N bare handed
+  fight N weapon       fight monster N with the equipped weapon
+  skip                 skip the room
+  position             show the position in text notation
+  take N|nothing       take relic N on offer between dungeons, or none
+  help                 show this help
+  quit                 stop playing`
+
+// runPlain plays a game line by line without Bubble Tea, printing the state
+// to out and reading commands from in until the game ends or in runs out
+func runPlain(in io.Reader, out io.Writer, m model) error {
+	// Scripts can't answer a confirmation prompt
+	m.settings.confirmLethal = false
+
+	scanner := bufio.NewScanner(in)
+	fmt.Fprintln(out, m.describeState())
+	for {
+		fmt.Fprint(out, "> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return scanner.Err()
+		}
+
+		switch strings.TrimSpace(strings.ToLower(scanner.Text())) {
+		case "help":
+			fmt.Fprintln(out, plainHelp)
+			continue
+		case "position":
+			fmt.Fprintln(out, m.position())
+			continue
+		case "quit", "q":
+			return nil
+		}
+
+		events := len(m.events)
+		if err := m.command(scanner.Text()); err != nil {
+			fmt.Fprintf(out, "error: %v\n", err)
+			continue
+		}
+
+		if len(m.events) > events {
+			fmt.Fprintln(out, m.announce(m.events[events:]))
+		}
+		if m.viewState == viewStateGameOver {
+			fmt.Fprintln(out, m.gameOverSummary())
+			return nil
+		}
+		fmt.Fprintln(out, m.describeState())
+	}
+}
+
+// command applies a single plain mode command to the game
+func (m *model) command(line string) error {
+	fields := strings.Fields(strings.ToLower(line))
+	if len(fields) == 0 {
+		return nil
+	}
+
+	if m.viewState == viewStateRelic && fields[0] != "take" {
+		return fmt.Errorf("choose a relic first: take N or take nothing")
+	}
+
+	switch fields[0] {
+	case "play":
+		if len(fields) < 2 {
+			return fmt.Errorf("usage: play N")
+		}
+		i, err := m.roomIndex(fields[1])
+		if err != nil {
+			return err
+		}
+		if isJoker(m.room[i]) {
+			return m.commandJoker(i, fields[2:])
+		}
+		if len(fields) != 2 {
+			return fmt.Errorf("usage: play N")
+		}
+		if c := m.room[i]; isMonster(c) && m.canUseWeapon(c) {
+			return fmt.Errorf("choose how to fight: fight %d fists or fight %d weapon", i+1, i+1)
+		}
+		m.selection = i
+		m.playRoom()
+
+	case "fight":
+		if len(fields) != 3 {
+			return fmt.Errorf("usage: fight N fists|weapon")
+		}
+		i, err := m.roomIndex(fields[1])
+		if err != nil {
+			return err
+		}
+		c := m.room[i]
+		if !isMonster(c) {
+			return fmt.Errorf("the %s isn't a monster", c)
+		}
+		switch fields[2] {
+		case "fists":
+			m.attackTypeSelection = int(withFists)
+		case "weapon":
+			if !m.canUseWeapon(c) {
+				return fmt.Errorf("your weapon can't hit the %s", c)
+			}
+			m.attackTypeSelection = int(withWeapon)
+		default:
+			return fmt.Errorf("fight with fists or weapon, not %q", fields[2])
+		}
+		m.selection = i
+		m.viewState = viewStateAttack
+		m.playAttack()
+
+	case "skip":
+		if !m.skippable {
+			return fmt.Errorf("this room can't be skipped")
+		}
+		m.selection = len(m.room)
+		m.playRoom()
+
+	case "take":
+		if m.viewState != viewStateRelic {
+			return fmt.Errorf("there are no relics on offer")
+		}
+		if len(fields) != 2 {
+			return fmt.Errorf("usage: take N or take nothing")
+		}
+		if fields[1] == "nothing" {
+			m.relicSelection = len(m.relicOffers)
+		} else {
+			n, err := strconv.Atoi(fields[1])
+			if err != nil || n < 1 || n > len(m.relicOffers) {
+				return fmt.Errorf("choose a relic from 1 to %d", len(m.relicOffers))
+			}
+			m.relicSelection = n - 1
+		}
+		m.takeRelic()
+
+	default:
+		return fmt.Errorf("unknown command %q, try help", fields[0])
+	}
+
+	return nil
+}
+
+// commandJoker plays the joker at i the way the rest of the command says
+func (m *model) commandJoker(i int, fields []string) error {
+	if len(fields) != 1 {
+		return fmt.Errorf("choose how to play the joker: play %d potion, play %d weapon or play %d flee", i+1, i+1, i+1)
+	}
+	switch fields[0] {
+	case "potion":
+		m.jokerSelection = int(asPotion)
+	case "weapon":
+		m.jokerSelection = int(asWeapon)
+	case "flee":
+		m.jokerSelection = int(asFlee)
+	default:
+		return fmt.Errorf("play the joker as a potion, weapon or flee, not %q", fields[0])
+	}
+	m.selection = i
+	m.viewState = viewStateJoker
+	m.playJoker()
+	return nil
+}
+
+// roomIndex parses a 1-based card number in the room
+func (m model) roomIndex(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 || n > len(m.room) {
+		return 0, fmt.Errorf("choose a card from 1 to %d", len(m.room))
+	}
+	return n - 1, nil
+}
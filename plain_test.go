@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+func TestRunPlain(t *testing.T) {
+	m := model{
+		rules: official,
+		life:  20,
+		dungeon: []deck.Card{
+			{Suit: deck.Spade, Rank: 2},
+			{Suit: deck.Heart, Rank: 4},
+			{Suit: deck.Club, Rank: 3},
+		},
+		room: []deck.Card{
+			{Suit: deck.Club, Rank: 6},
+			{Suit: deck.Diamond, Rank: 5},
+			{Suit: deck.Spade, Rank: 9},
+			{Suit: deck.Heart, Rank: 3},
+		},
+		skippable: true,
+		viewState: viewStateRoom,
+	}
+
+	in := strings.NewReader(strings.Join([]string{
+		"play 2",
+		"play 1",
+		"fight 1 weapon",
+		"skip",
+		"fight 1 fists",
+		"play 3",
+		"fight 2 weapon",
+		"fight 2 weapon",
+		"fight 2 fists",
+		"play 1",
+		"play 1",
+	}, "\n"))
+	var out bytes.Buffer
+	if err := runPlain(in, &out, m); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		"Room: 1. Six of Clubs monster, strength 6; 2. Five of Diamonds weapon, power 5; 3. Nine of Spades monster, strength 9; 4. Three of Hearts potion, heals 3; 5. Skip this room.",
+		"> You equipped the Five of Diamonds. Life is now 20.",
+		"> error: choose how to fight: fight 1 fists or fight 1 weapon",
+		"> You slew the Six of Clubs with your weapon and took 1 damage. Life is now 19.",
+		"> error: this room can't be skipped",
+		"> You fought the Nine of Spades with your fists and took 9 damage. Room cleared. Life is now 10.",
+		"> You drank the Four of Hearts and healed 4. Life is now 14.",
+		"> You slew the Two of Spades with your weapon and took 0 damage. Life is now 14.",
+		"> error: your weapon can't hit the Three of Clubs",
+		"> You fought the Three of Clubs with your fists and took 3 damage. Life is now 11.",
+		"> You drank the Three of Hearts and healed 3. Room cleared. Life is now 14.",
+		"Victory",
+		"Score: 17 (life 14 + last potion 3)",
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected output to contain %q, got\n%s", expected, out.String())
+		}
+	}
+	if strings.Count(out.String(), "> ") != 10 {
+		t.Errorf("expected the game to end after 10 commands, got\n%s", out.String())
+	}
+}
+
+func TestCommand(t *testing.T) {
+	tests := []struct {
+		command  string
+		expected string
+	}{
+		{"", ""},
+		{"dance", `unknown command "dance", try help`},
+		{"play", "usage: play N"},
+		{"play 0", "choose a card from 1 to 4"},
+		{"play five", "choose a card from 1 to 4"},
+		{"fight 1", "usage: fight N fists|weapon"},
+		{"fight 3 fists", "the Eight of Hearts isn't a monster"},
+		{"fight 1 sword", `fight with fists or weapon, not "sword"`},
+		{"fight 1 weapon", "your weapon can't hit the Six of Clubs"},
+		{"PLAY 3", ""},
+	}
+
+	for _, tt := range tests {
+		m := model{
+			rules:     official,
+			life:      20,
+			dungeon:   testDungeon(),
+			room:      testRoom(4),
+			viewState: viewStateRoom,
+		}
+		err := m.command(tt.command)
+		if tt.expected == "" && err != nil {
+			t.Errorf("expected %q to succeed, got %v", tt.command, err)
+		}
+		if tt.expected != "" && (err == nil || err.Error() != tt.expected) {
+			t.Errorf("expected %q to fail with %q, got %v", tt.command, tt.expected, err)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+// Position is everything about a moment in a game that decides how it can
+// play out, given the rules. It's written as five fields separated by
+// spaces, much like FEN in chess:
+//
+//	9S,2H,KC 4H,8S,3C,7D 15 10D/QC,9S s
+//
+// The dungeon top card first, the room, the life, the weapon with the
+// monsters it slew oldest first, and s if the room can be skipped. An empty
+// list, no weapon or a room that can't be skipped is written as -.
+type Position struct {
+	Dungeon   []deck.Card
+	Room      []deck.Card
+	Life      int
+	Weapon    deck.Card
+	Slain     []deck.Card
+	Skippable bool
+}
+
+// ParsePosition reads a position written by Position.String
+func ParsePosition(s string) (Position, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 5 {
+		return Position{}, fmt.Errorf("a position has 5 fields, dungeon room life weapon skippable, got %d", len(fields))
+	}
+
+	var p Position
+	var err error
+	if p.Dungeon, err = parseCardList(fields[0]); err != nil {
+		return Position{}, fmt.Errorf("dungeon: %w", err)
+	}
+	if p.Room, err = parseCardList(fields[1]); err != nil {
+		return Position{}, fmt.Errorf("room: %w", err)
+	}
+	if p.Life, err = strconv.Atoi(fields[2]); err != nil || p.Life < 0 {
+		return Position{}, fmt.Errorf("life: expected a whole number, got %s", fields[2])
+	}
+
+	weaponField, slainField, _ := strings.Cut(fields[3], "/")
+	if weaponField != "-" {
+		if p.Weapon, err = deck.ParseCard(weaponField); err != nil {
+			return Position{}, fmt.Errorf("weapon: %w", err)
+		}
+	}
+	if slainField != "" {
+		if p.Slain, err = parseCardList(slainField); err != nil {
+			return Position{}, fmt.Errorf("slain: %w", err)
+		}
+	}
+	if p.Weapon == (deck.Card{}) && len(p.Slain) > 0 {
+		return Position{}, fmt.Errorf("slain: monsters slain without a weapon")
+	}
+
+	switch fields[4] {
+	case "s":
+		p.Skippable = true
+	case "-":
+	default:
+		return Position{}, fmt.Errorf("skippable: expected s or -, got %s", fields[4])
+	}
+	return p, nil
+}
+
+func (p Position) String() string {
+	weapon := "-"
+	if p.Weapon != (deck.Card{}) {
+		weapon = p.Weapon.Short()
+		if len(p.Slain) > 0 {
+			weapon += "/" + cardList(p.Slain)
+		}
+	}
+	skippable := "-"
+	if p.Skippable {
+		skippable = "s"
+	}
+	return fmt.Sprintf("%s %s %d %s %s", cardList(p.Dungeon), cardList(p.Room), p.Life, weapon, skippable)
+}
+
+// validate rejects a position that can't come up under the rules, with more
+// life than they allow or a card in more places than there are copies of it
+func (p Position) validate(r Rules) error {
+	if p.Life < 1 || p.Life > r.MaxLife {
+		return fmt.Errorf("life must be from 1 to %d, got %d", r.MaxLife, p.Life)
+	}
+
+	seen := map[deck.Card]int{}
+	cards := slices.Concat(p.Dungeon, p.Room, p.Slain)
+	if p.Weapon != (deck.Card{}) {
+		cards = append(cards, p.Weapon)
+	}
+	for _, c := range cards {
+		seen[c]++
+		copies := r.composition().Copies
+		if isJoker(c) {
+			copies = 1
+		}
+		if seen[c] > copies {
+			return fmt.Errorf("the %s is in the position more times than there are copies of it", c)
+		}
+	}
+	return nil
+}
+
+// position is where the game stands
+func (m model) position() Position {
+	return Position{
+		Dungeon:   slices.Clone(m.dungeon),
+		Room:      slices.Clone(m.room),
+		Life:      m.life,
+		Weapon:    m.weapon.card,
+		Slain:     slices.Clone(m.weapon.slain),
+		Skippable: m.skippable,
+	}
+}
+
+// newPosition starts a game from a position. The room is dealt from the
+// dungeon if the position has none.
+func newPosition(p Position, r Rules, s settings) model {
+	m := newGame(0, r, s)
+	m.dungeon = slices.Clone(p.Dungeon)
+	m.room = slices.Clone(p.Room)
+	m.life = p.Life
+	m.weapon = weapon{card: p.Weapon, slain: slices.Clone(p.Slain)}
+	if m.weapon.slain == nil {
+		m.weapon.slain = deck.Pile{}
+	}
+	m.skippable = p.Skippable
+	m.dealt = 0
+
+	if len(m.room) == 0 {
+		m.drawToRoom(r.RoomSize)
+	}
+	m.shownLife = m.life
+	return m
+}
+
+// cardList writes cards separated by commas, or - for none
+func cardList(cards []deck.Card) string {
+	if len(cards) == 0 {
+		return "-"
+	}
+	codes := make([]string, len(cards))
+	for i, c := range cards {
+		codes[i] = c.Short()
+	}
+	return strings.Join(codes, ",")
+}
+
+func parseCardList(s string) ([]deck.Card, error) {
+	if s == "-" {
+		return nil, nil
+	}
+	return parseCards(strings.Split(s, ","))
+}
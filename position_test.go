@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+func TestPositionRoundTrip(t *testing.T) {
+	tests := []string{
+		"9S,2H,KC 4H,8S,3C,7D 15 10D/QC,9S s",
+		"- AS 3 - -",
+		"JOKER,2C - 20 5D s",
+	}
+
+	for _, test := range tests {
+		p, err := ParsePosition(test)
+		if err != nil {
+			t.Fatalf("expected %q to parse, got %v", test, err)
+		}
+		if p.String() != test {
+			t.Errorf("expected position to be %q, got %q", test, p.String())
+		}
+	}
+}
+
+func TestParsePosition(t *testing.T) {
+	p, err := ParsePosition("9S,2H 4H,8S 15 10D/QC s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Position{
+		Dungeon:   []deck.Card{{Suit: deck.Spade, Rank: deck.Nine}, {Suit: deck.Heart, Rank: deck.Two}},
+		Room:      []deck.Card{{Suit: deck.Heart, Rank: deck.Four}, {Suit: deck.Spade, Rank: deck.Eight}},
+		Life:      15,
+		Weapon:    deck.Card{Suit: deck.Diamond, Rank: deck.Ten},
+		Slain:     []deck.Card{{Suit: deck.Club, Rank: deck.Queen}},
+		Skippable: true,
+	}
+	if !reflect.DeepEqual(p, expected) {
+		t.Errorf("expected position to be %+v, got %+v", expected, p)
+	}
+}
+
+func TestParsePositionErrors(t *testing.T) {
+	tests := []struct {
+		position string
+		err      string
+	}{
+		{"9S 4H 15 -", "5 fields"},
+		{"9S 4X 15 - s", "room:"},
+		{"9S 4H many - s", "life:"},
+		{"9S 4H 15 -/QC s", "without a weapon"},
+		{"9S 4H 15 - yes", "skippable:"},
+	}
+
+	for _, test := range tests {
+		_, err := ParsePosition(test.position)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("expected error containing %q, got %v", test.err, err)
+		}
+	}
+}
+
+func TestPositionValidate(t *testing.T) {
+	tests := []struct {
+		position string
+		err      string
+	}{
+		{"9S 4H 15 10D/QC s", ""},
+		{"9S 4H 21 - s", "life must be from 1 to 20"},
+		{"9S 4H 0 - s", "life must be from 1 to 20"},
+		{"9S,4H 4H 15 - s", "Four of Hearts"},
+		{"9S 4H 15 10D/9S s", "Nine of Spades"},
+		{"JOKER,JOKER 4H 15 - s", "Joker"},
+	}
+
+	for _, test := range tests {
+		p, err := ParsePosition(test.position)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = p.validate(official)
+		if test.err == "" && err != nil || test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+			t.Errorf("expected %q to fail with %q, got %v", test.position, test.err, err)
+		}
+	}
+
+	// Two decks have two of each card
+	double := official
+	double.Deck = standardComposition
+	double.Deck.Copies = 2
+	p, _ := ParsePosition("9S,4H 4H 15 - s")
+	if err := p.validate(double); err != nil {
+		t.Errorf("expected two of a card to fit two decks, got %v", err)
+	}
+}
+
+func TestModelPosition(t *testing.T) {
+	m := newGame(1, official, settings{})
+	m.weapon = weapon{card: deck.Card{Suit: deck.Diamond, Rank: deck.Five}, slain: []deck.Card{{Suit: deck.Club, Rank: deck.Four}}}
+
+	again := newPosition(m.position(), official, settings{})
+	if again.position().String() != m.position().String() {
+		t.Errorf("expected position to be %q, got %q", m.position(), again.position())
+	}
+}
+
+func TestPositionCommands(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"solve", "--position", "2H,3S 5D,4S 10 - -"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected solving a position to work, got %s", errOut.String())
+	}
+	if !strings.Contains(out.String(), "Best score: 14") {
+		t.Errorf("expected a best score of 14, got %q", out.String())
+	}
+
+	out.Reset()
+	m := newPosition(Position{Room: []deck.Card{{Suit: deck.Heart, Rank: deck.Two}}, Life: 5}, official, settings{})
+	if err := runPlain(strings.NewReader("position\nquit\n"), &out, m); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "- 2H 5 - -") {
+		t.Errorf("expected the position to be shown, got %q", out.String())
+	}
+}
@@ -0,0 +1,353 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+//go:embed puzzles.json
+var bundledPuzzles []byte
+
+// puzzle is a fixed position with a goal to reach
+type puzzle struct {
+	name        string
+	description string
+	rules       Rules
+	start       Position
+	goal        puzzleGoal
+}
+
+// Kinds of goal a puzzle can have
+const (
+	// Clear the dungeon with at least the goal's life left
+	goalClear = "clear"
+
+	// Slay the goal's card with a weapon and live
+	goalSlay = "slay"
+)
+
+type puzzleGoal struct {
+	kind string
+	life int
+	card deck.Card
+}
+
+// puzzleFile is the form of a puzzle in a pack, with cards written like
+// "10S", "KH" or "JOKER". The start is either a whole Position, or a
+// dungeon dealt in order, first card first, with the life and weapon.
+type puzzleFile struct {
+	Name        string
+	Description string
+	Variant     string
+	Position    string
+	Dungeon     []string
+	Life        int
+	Weapon      string
+	Slain       []string
+	Goal        struct {
+		Kind string
+		Life int
+		Card string
+	}
+}
+
+// loadPuzzles reads the bundled puzzle pack
+func loadPuzzles() ([]puzzle, error) {
+	return parsePuzzles(bundledPuzzles)
+}
+
+// parsePuzzles reads a puzzle pack, a JSON list of puzzles
+func parsePuzzles(data []byte) ([]puzzle, error) {
+	var files []puzzleFile
+	if err := json.Unmarshal(data, &files); err != nil {
+		return nil, err
+	}
+
+	// Solved puzzles and saves find their puzzle by name
+	names := map[string]bool{}
+	var puzzles []puzzle
+	for _, f := range files {
+		if names[f.Name] {
+			return nil, fmt.Errorf("puzzle %q: the name is taken by another puzzle", f.Name)
+		}
+		names[f.Name] = true
+
+		p, err := f.puzzle()
+		if err != nil {
+			return nil, fmt.Errorf("puzzle %q: %w", f.Name, err)
+		}
+		puzzles = append(puzzles, p)
+	}
+	return puzzles, nil
+}
+
+// puzzle checks a puzzle from a pack and turns it into one to play
+func (f puzzleFile) puzzle() (puzzle, error) {
+	if f.Variant == "" {
+		f.Variant = defaultVariant
+	}
+	r, ok := presets[f.Variant]
+	if !ok {
+		return puzzle{}, fmt.Errorf("unknown variant %q", f.Variant)
+	}
+	start, err := f.start(r)
+	if err != nil {
+		return puzzle{}, err
+	}
+	if err := start.validate(r); err != nil {
+		return puzzle{}, err
+	}
+	if len(start.Dungeon) == 0 && len(start.Room) == 0 {
+		return puzzle{}, fmt.Errorf("the dungeon is empty")
+	}
+	if start.Weapon != (deck.Card{}) && start.Weapon.Suit != deck.Diamond && !isJoker(start.Weapon) {
+		return puzzle{}, fmt.Errorf("the weapon must be a diamond, got the %s", start.Weapon)
+	}
+	r.StartLife = start.Life
+
+	p := puzzle{
+		name:        f.Name,
+		description: f.Description,
+		rules:       r,
+		start:       start,
+	}
+
+	p.goal = puzzleGoal{kind: f.Goal.Kind, life: f.Goal.Life}
+	switch f.Goal.Kind {
+	case goalClear:
+	case goalSlay:
+		if f.Goal.Life != 0 {
+			return puzzle{}, fmt.Errorf("a slay goal can't ask for life, only a clear goal can")
+		}
+		if p.goal.card, err = deck.ParseCard(f.Goal.Card); err != nil {
+			return puzzle{}, err
+		}
+		if !slices.Contains(p.start.Dungeon, p.goal.card) && !slices.Contains(p.start.Room, p.goal.card) {
+			return puzzle{}, fmt.Errorf("the %s to slay isn't in the dungeon", p.goal.card)
+		}
+	default:
+		return puzzle{}, fmt.Errorf("unknown goal %q, use clear or slay", f.Goal.Kind)
+	}
+	return p, nil
+}
+
+// start reads the position a puzzle starts from
+func (f puzzleFile) start(r Rules) (Position, error) {
+	if f.Position != "" {
+		return ParsePosition(f.Position)
+	}
+
+	p := Position{Life: r.StartLife, Skippable: true}
+	if f.Life != 0 {
+		p.Life = f.Life
+	}
+
+	var err error
+	if p.Dungeon, err = parseCards(f.Dungeon); err != nil {
+		return Position{}, err
+	}
+	if f.Weapon != "" {
+		if p.Weapon, err = deck.ParseCard(f.Weapon); err != nil {
+			return Position{}, err
+		}
+	}
+	if len(f.Slain) > 0 && f.Weapon == "" {
+		return Position{}, fmt.Errorf("monsters slain without a weapon")
+	}
+	if p.Slain, err = parseCards(f.Slain); err != nil {
+		return Position{}, err
+	}
+	return p, nil
+}
+
+func parseCards(ss []string) ([]deck.Card, error) {
+	var cards []deck.Card
+	for _, s := range ss {
+		c, err := deck.ParseCard(s)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, c)
+	}
+	return cards, nil
+}
+
+// String states the goal
+func (g puzzleGoal) String() string {
+	if g.kind == goalSlay {
+		return fmt.Sprintf("Slay the %s with a weapon", g.card)
+	}
+	if g.life > 0 {
+		return fmt.Sprintf("Clear the dungeon with at least %d life", g.life)
+	}
+	return "Clear the dungeon"
+}
+
+// newPuzzle deals a puzzle ready to play
+func newPuzzle(p puzzle, s settings) model {
+	m := newPosition(p.start, p.rules, s)
+	m.puzzle = &p
+	return m
+}
+
+func (m model) inPuzzle() bool {
+	return m.puzzle != nil
+}
+
+// solved reports whether the puzzle's goal has been reached
+func (m model) solved() bool {
+	if m.life <= 0 {
+		return false
+	}
+	g := m.puzzle.goal
+	if g.kind == goalSlay {
+		return slices.ContainsFunc(m.events, func(e event) bool {
+			return e.Kind == eventWeapon && e.Card == g.card
+		})
+	}
+	return m.won() && m.life >= g.life
+}
+
+const solvedFile = "solved.json"
+
+// loadSolved reads the names of the puzzles solved so far
+func loadSolved(dir string) (map[string]bool, error) {
+	data, err := os.ReadFile(filepath.Join(dir, solvedFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]bool{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	solved := map[string]bool{}
+	err = json.Unmarshal(data, &solved)
+	return solved, err
+}
+
+// recordPuzzle marks a puzzle as solved in dir
+func recordPuzzle(dir string, name string) error {
+	solved, err := loadSolved(dir)
+	if err != nil {
+		return err
+	}
+	solved[name] = true
+
+	data, err := json.Marshal(solved)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, solvedFile), data, 0o644)
+}
+
+// findPuzzle looks up a bundled puzzle by name, as saves refer to them
+func findPuzzle(name string) (*puzzle, error) {
+	puzzles, err := loadPuzzles()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range puzzles {
+		if p.name == name {
+			return &p, nil
+		}
+	}
+	return nil, fmt.Errorf("no puzzle called %q", name)
+}
+
+// puzzlesModel is the screen for browsing the puzzles
+type puzzlesModel struct {
+	settings  settings
+	puzzles   []puzzle
+	solved    map[string]bool
+	selection int
+	err       error
+
+	// Terminal dimensions
+	width  int
+	height int
+}
+
+func showPuzzles(s settings) tea.Cmd {
+	return func() tea.Msg {
+		puzzles, err := loadPuzzles()
+		if err != nil {
+			return switchScreenMsg{puzzlesModel{settings: s, err: err}}
+		}
+		solved, err := loadSolved(s.dataDir)
+		return switchScreenMsg{puzzlesModel{settings: s, puzzles: puzzles, solved: solved, err: err}}
+	}
+}
+
+func (m puzzlesModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m puzzlesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+
+		switch m.settings.keys.action(msg.String()) {
+		case keyBack:
+			return m, switchTo(newMenu(m.settings))
+		case keyUp:
+			if len(m.puzzles) > 0 {
+				m.selection = (m.selection - 1 + len(m.puzzles)) % len(m.puzzles)
+			}
+		case keyDown:
+			if len(m.puzzles) > 0 {
+				m.selection = (m.selection + 1) % len(m.puzzles)
+			}
+		case keyChoose:
+			if len(m.puzzles) > 0 {
+				return m, switchTo(newPuzzle(m.puzzles[m.selection], m.settings))
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m puzzlesModel) View() string {
+	s := m.settings.glyphs().puzzlesTitle + "\n\n"
+	if m.err != nil {
+		s += m.settings.styles().danger.Render(fmt.Sprintf("Couldn't load puzzles: %v", m.err)) + "\n\n"
+	}
+
+	for i, p := range m.puzzles {
+		cursor := " "
+		if m.selection == i {
+			cursor = ">"
+		}
+		check := " "
+		if m.solved[p.name] {
+			check = m.settings.glyphs().solved
+		}
+		s += fmt.Sprintf("%s %s %s\n", cursor, check, p.name)
+	}
+	if len(m.puzzles) > 0 {
+		p := m.puzzles[m.selection]
+		s += fmt.Sprintf("\n%s\nGoal: %s. Rules: %s.\n", p.description, p.goal, p.rules)
+	}
+	s += "\nPress enter to play. Press q to go back."
+
+	return placeView(s, m.width, m.height)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBundledPuzzlesSolvable(t *testing.T) {
+	puzzles, err := loadPuzzles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(puzzles) == 0 {
+		t.Fatal("expected some bundled puzzles")
+	}
+
+	for _, p := range puzzles {
+		if _, ok := solvePuzzle(newPuzzle(p, settings{})); !ok {
+			t.Errorf("expected the puzzle %q to be solvable", p.name)
+		}
+	}
+}
+
+func TestParsePuzzlesErrors(t *testing.T) {
+	tests := []struct {
+		pack string
+		err  string
+	}{
+		{`[{"Name": "a", "Dungeon": [], "Goal": {"Kind": "clear"}}]`, "the dungeon is empty"},
+		{`[{"Name": "a", "Dungeon": ["2S"], "Goal": {"Kind": "win"}}]`, `unknown goal "win"`},
+		{`[{"Name": "a", "Dungeon": ["2S"], "Goal": {"Kind": "slay", "Card": "KS"}}]`, "isn't in the dungeon"},
+		{`[{"Name": "a", "Dungeon": ["2S"], "Weapon": "5S", "Goal": {"Kind": "clear"}}]`, "must be a diamond"},
+		{`[{"Name": "a", "Dungeon": ["2S"], "Life": 30, "Goal": {"Kind": "clear"}}]`, "life must be"},
+		{`[{"Name": "a", "Dungeon": ["2S"], "Goal": {"Kind": "slay", "Card": "2S", "Life": 5}}]`, "can't ask for life"},
+		{`[{"Name": "a", "Dungeon": ["2S"], "Goal": {"Kind": "clear"}}, {"Name": "a", "Dungeon": ["3S"], "Goal": {"Kind": "clear"}}]`, "name is taken"},
+	}
+
+	for _, test := range tests {
+		_, err := parsePuzzles([]byte(test.pack))
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("expected error containing %q, got %v", test.err, err)
+		}
+	}
+}
+
+func TestSlayGoal(t *testing.T) {
+	puzzles, err := parsePuzzles([]byte(`[{
+		"Name": "slay",
+		"Life": 10,
+		"Weapon": "8D",
+		"Dungeon": ["9S", "2H", "3H", "4H", "5H", "6H"],
+		"Goal": {"Kind": "slay", "Card": "9S"}
+	}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := newPuzzle(puzzles[0], settings{})
+	if err := m.command("fight 1 weapon"); err != nil {
+		t.Fatal(err)
+	}
+	if m.viewState != viewStateGameOver || !m.solved() {
+		t.Errorf("expected slaying the 9 of Spades to solve the puzzle, got view %s", m.viewState)
+	}
+	assertExpectedLife(t, m.life, 9)
+}
+
+func TestRecordPuzzle(t *testing.T) {
+	dir := t.TempDir()
+	if err := recordPuzzle(dir, "First steps"); err != nil {
+		t.Fatal(err)
+	}
+
+	solved, err := loadSolved(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !solved["First steps"] || solved["Run away"] {
+		t.Errorf("expected only First steps to be solved, got %v", solved)
+	}
+}
+
+func TestSavePuzzle(t *testing.T) {
+	puzzles, err := loadPuzzles()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	if err := saveGame(dir, newPuzzle(puzzles[0], settings{})); err != nil {
+		t.Fatal(err)
+	}
+	m, err := loadGame(dir, settings{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.inPuzzle() || m.puzzle.name != puzzles[0].name {
+		t.Errorf("expected the puzzle %q to be continued", puzzles[0].name)
+	}
+}
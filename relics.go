@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+// relic changes the game through hooks on what happens in it. Any hook can
+// be left nil. Relics are found between the dungeons of a campaign.
+type relic struct {
+	description string
+
+	// healing adjusts how much a potion heals
+	healing func(m model, c deck.Card, heal int) int
+
+	// damage adjusts how much damage a monster deals, fought either way
+	damage func(m model, c deck.Card, how attackType, damage int) int
+
+	// Hooks run after a weapon is equipped, a monster is slain, a room is
+	// skipped and a new room is dealt
+	equipped func(m *model, c deck.Card, previous weapon)
+	slain    func(m *model, c deck.Card, how attackType)
+	skipped  func(m *model)
+	drawn    func(m *model)
+}
+
+var allRelics = map[string]relic{
+	"Chalice": {
+		description: "the first potion drunk in each room heals 2 more",
+		healing: func(m model, c deck.Card, heal int) int {
+			if m.roomPotions == 0 {
+				return heal + 2
+			}
+			return heal
+		},
+	},
+	"Knuckledusters": {
+		description: "clubs fought with fists deal 1 less damage",
+		damage: func(m model, c deck.Card, how attackType, damage int) int {
+			if how == withFists && c.Suit == deck.Club {
+				return max(0, damage-1)
+			}
+			return damage
+		},
+	},
+	"Bloodstone": {
+		description: "each monster slain with a weapon heals 1",
+		slain: func(m *model, c deck.Card, how attackType) {
+			if how == withWeapon {
+				m.relicHeal("Bloodstone", 1)
+			}
+		},
+	},
+	"Cloak": {
+		description: "skipping a room heals 2",
+		skipped: func(m *model) {
+			m.relicHeal("Cloak", 2)
+		},
+	},
+	"Compass": {
+		description: "a room dealt without a potion in it heals 1",
+		drawn: func(m *model) {
+			if !slices.ContainsFunc(m.room, func(c deck.Card) bool { return c.Suit == deck.Heart }) {
+				m.relicHeal("Compass", 1)
+			}
+		},
+	},
+	"Scabbard": {
+		description: "swapping one weapon for another heals 2",
+		equipped: func(m *model, c deck.Card, previous weapon) {
+			if previous.equipped() {
+				m.relicHeal("Scabbard", 2)
+			}
+		},
+	},
+}
+
+// relicOffers is how many relics are offered between dungeons
+const relicOffers = 3
+
+// relicNames lists the relics in a stable order
+func relicNames() []string {
+	var names []string
+	for name := range allRelics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// relicHeal heals from a relic, logging it as its own event
+func (m *model) relicHeal(name string, heal int) {
+	// Nothing brings back the dead
+	if m.life <= 0 {
+		return
+	}
+	before := m.life
+	m.life = min(m.rules.MaxLife, m.life+heal)
+	if m.life != before {
+		m.events = append(m.events, event{Kind: eventRelic, Relic: name, Before: before, After: m.life})
+	}
+}
+
+// potionHealing is how much a potion heals with the relics held
+func (m model) potionHealing(c deck.Card) int {
+	heal := m.rules.value(c)
+	for _, name := range m.relics {
+		if r := allRelics[name]; r.healing != nil {
+			heal = r.healing(m, c, heal)
+		}
+	}
+	return heal
+}
+
+// relicDamage adjusts the damage of a fight with the relics held
+func (m model) relicDamage(c deck.Card, how attackType, damage int) int {
+	for _, name := range m.relics {
+		if r := allRelics[name]; r.damage != nil {
+			damage = r.damage(m, c, how, damage)
+		}
+	}
+	return damage
+}
+
+func (m *model) relicsEquipped(c deck.Card, previous weapon) {
+	for _, name := range m.relics {
+		if r := allRelics[name]; r.equipped != nil {
+			r.equipped(m, c, previous)
+		}
+	}
+}
+
+func (m *model) relicsSlain(c deck.Card, how attackType) {
+	for _, name := range m.relics {
+		if r := allRelics[name]; r.slain != nil {
+			r.slain(m, c, how)
+		}
+	}
+}
+
+func (m *model) relicsSkipped() {
+	for _, name := range m.relics {
+		if r := allRelics[name]; r.skipped != nil {
+			r.skipped(m)
+		}
+	}
+}
+
+// roomDrawn starts a freshly dealt room
+func (m *model) roomDrawn() {
+	m.roomPotions = 0
+	for _, name := range m.relics {
+		if r := allRelics[name]; r.drawn != nil {
+			r.drawn(m)
+		}
+	}
+}
+
+// offerRelics picks relics not yet held to choose from before the next
+// dungeon, the same ones for the same seed and depth
+func (m *model) offerRelics() {
+	var unheld []string
+	for _, name := range relicNames() {
+		if !slices.Contains(m.relics, name) {
+			unheld = append(unheld, name)
+		}
+	}
+	if len(unheld) == 0 {
+		return
+	}
+
+	r := rand.New(rand.NewSource(m.seed + int64(m.campaign.depth)))
+	r.Shuffle(len(unheld), func(i, j int) { unheld[i], unheld[j] = unheld[j], unheld[i] })
+	m.relicOffers = unheld[:min(relicOffers, len(unheld))]
+	m.relicSelection = 0
+	m.viewState = viewStateRelic
+}
+
+// takeRelic takes the selected relic on offer, or none if the selection is
+// past the offers. Either way the choice is logged so replays can make it.
+func (m *model) takeRelic() {
+	name := ""
+	if m.relicSelection < len(m.relicOffers) {
+		name = m.relicOffers[m.relicSelection]
+		m.relics = append(m.relics, name)
+	}
+	m.events = append(m.events, event{Kind: eventTakeRelic, Relic: name, Before: m.life, After: m.life})
+	m.relicOffers = nil
+	m.relicSelection = 0
+	m.viewState = viewStateRoom
+}
+
+func (m model) chooseRelicView() string {
+	header := m.headerView()
+	footer := m.footerView()
+	selectionLines, _ := m.relicLines()
+
+	return layoutView(header, selectionLines, footer, m.layoutWidth(), m.height)
+}
+
+// relicLines returns the selection lines of the relic view along with the
+// offer each line maps to, or -1 for spacing lines
+func (m model) relicLines() ([]string, []int) {
+	cursor := map[bool]string{true: ">", false: " "}
+
+	selectionLines := []string{fmt.Sprintf("Depth %d. Take a relic for the way down:", m.campaign.depth), ""}
+	targets := []int{-1, -1}
+	for i, name := range m.relicOffers {
+		selectionLines = append(selectionLines, fmt.Sprintf("%s %s: %s", cursor[m.relicSelection == i], name, allRelics[name].description))
+		targets = append(targets, i)
+	}
+	selectionLines = append(selectionLines, "")
+	selectionLines = append(selectionLines, fmt.Sprintf("%s Take nothing", cursor[m.relicSelection == len(m.relicOffers)]))
+	targets = append(targets, -1, len(m.relicOffers))
+
+	return selectionLines, targets
+}
+
+// relicsView lists the relics held
+func (m model) relicsView() string {
+	if len(m.relics) == 0 {
+		return "none"
+	}
+	return strings.Join(m.relics, ", ")
+}
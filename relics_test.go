@@ -0,0 +1,177 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+func relicRoom(relics ...string) model {
+	return model{
+		rules:   official,
+		life:    10,
+		relics:  relics,
+		dungeon: testDungeon(),
+		room: []deck.Card{
+			{Suit: deck.Heart, Rank: 3},
+			{Suit: deck.Club, Rank: 4},
+			{Suit: deck.Diamond, Rank: 5},
+			{Suit: deck.Heart, Rank: 2},
+		},
+		weapon:    weapon{slain: []deck.Card{}},
+		viewState: viewStateRoom,
+	}
+}
+
+func TestRelicHooks(t *testing.T) {
+	tests := []struct {
+		relic        string
+		play         func(m *model)
+		expectedLife int
+	}{
+		// The second potion of the room isn't boosted
+		{"Chalice", func(m *model) { m.usePotion(m.room[0]); m.usePotion(m.room[3]) }, 17},
+		{"Knuckledusters", func(m *model) { m.attackWithFists(m.room[1]) }, 7},
+		{"Bloodstone", func(m *model) {
+			m.equipWeapon(m.room[2])
+			m.attackWithWeapon(m.room[1])
+		}, 11},
+		{"Cloak", func(m *model) { m.skipRoom() }, 12},
+		{"Scabbard", func(m *model) { m.equipWeapon(m.room[2]); m.equipWeapon(m.room[2]) }, 12},
+	}
+
+	for _, test := range tests {
+		m := relicRoom(test.relic)
+		test.play(&m)
+		if m.life != test.expectedLife {
+			t.Errorf("expected life with the %s to be %d, got %d", test.relic, test.expectedLife, m.life)
+		}
+
+		without := relicRoom()
+		test.play(&without)
+		if without.life == test.expectedLife {
+			t.Errorf("expected the %s to change life, got %d either way", test.relic, without.life)
+		}
+	}
+}
+
+func TestCompass(t *testing.T) {
+	m := relicRoom("Compass")
+	m.room = []deck.Card{{Suit: deck.Club, Rank: 2}}
+	m.roomDrawn()
+	assertExpectedLife(t, m.life, 11)
+
+	m.room = []deck.Card{{Suit: deck.Heart, Rank: 2}}
+	m.roomDrawn()
+	assertExpectedLife(t, m.life, 11)
+}
+
+func TestRelicsDontRevive(t *testing.T) {
+	m := relicRoom("Cloak")
+	m.life = 0
+	m.relicsSkipped()
+	assertExpectedLife(t, m.life, 0)
+}
+
+func TestOfferRelics(t *testing.T) {
+	m := newCampaign(3, official, settings{})
+	m.campaign.depth = 2
+	m.relics = []string{"Cloak"}
+	m.offerRelics()
+
+	if m.viewState != viewStateRelic {
+		t.Errorf("expected view to be %s, got %s", viewStateRelic, m.viewState)
+	}
+	if len(m.relicOffers) != relicOffers {
+		t.Errorf("expected offers to be %d, got %d", relicOffers, len(m.relicOffers))
+	}
+	for _, name := range m.relicOffers {
+		if name == "Cloak" {
+			t.Errorf("expected a relic already held not to be offered again")
+		}
+	}
+
+	again := newCampaign(3, official, settings{})
+	again.campaign.depth = 2
+	again.relics = []string{"Cloak"}
+	again.offerRelics()
+	if !reflect.DeepEqual(m.relicOffers, again.relicOffers) {
+		t.Errorf("expected the same offers for the same seed, got %v and %v", m.relicOffers, again.relicOffers)
+	}
+}
+
+func TestTakeRelic(t *testing.T) {
+	tests := []struct {
+		selection      int
+		expectedRelics []string
+	}{
+		{1, []string{"Cloak"}},
+		{2, nil},
+	}
+
+	for _, test := range tests {
+		m := relicRoom()
+		m.relicOffers = []string{"Chalice", "Cloak"}
+		m.relicSelection = test.selection
+		m.viewState = viewStateRelic
+
+		m.takeRelic()
+
+		if !reflect.DeepEqual(m.relics, test.expectedRelics) {
+			t.Errorf("expected relics to be %v, got %v", test.expectedRelics, m.relics)
+		}
+		if m.viewState != viewStateRoom || m.relicOffers != nil {
+			t.Errorf("expected the offer to be over, got view %s with %v", m.viewState, m.relicOffers)
+		}
+		if last := m.events[len(m.events)-1]; last.Kind != eventTakeRelic {
+			t.Errorf("expected the choice to be logged, got %s", last.Kind)
+		}
+	}
+}
+
+func TestPlainTake(t *testing.T) {
+	m := relicRoom()
+	m.relicOffers = []string{"Chalice", "Cloak"}
+	m.viewState = viewStateRelic
+
+	if err := m.command("play 1"); err == nil {
+		t.Errorf("expected playing a card with relics on offer to fail")
+	}
+	if err := m.command("take 3"); err == nil {
+		t.Errorf("expected taking a relic not on offer to fail")
+	}
+	if err := m.command("take 2"); err != nil {
+		t.Fatalf("expected take 2 to work, got %v", err)
+	}
+	if !reflect.DeepEqual(m.relics, []string{"Cloak"}) {
+		t.Errorf("expected relics to be [Cloak], got %v", m.relics)
+	}
+}
+
+func TestSaveRelics(t *testing.T) {
+	dir := t.TempDir()
+	m := newCampaign(3, official, settings{})
+	m.relics = []string{"Chalice"}
+	m.relicOffers = []string{"Cloak", "Compass"}
+	m.roomPotions = 1
+	m.viewState = viewStateRelic
+
+	if err := saveGame(dir, m); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := loadGame(dir, settings{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(loaded.relics, m.relics) || !reflect.DeepEqual(loaded.relicOffers, m.relicOffers) {
+		t.Errorf("expected relics %v and offers %v, got %v and %v", m.relics, m.relicOffers, loaded.relics, loaded.relicOffers)
+	}
+	if loaded.roomPotions != 1 {
+		t.Errorf("expected room potions to be 1, got %d", loaded.roomPotions)
+	}
+	if loaded.viewState != viewStateRelic {
+		t.Errorf("expected view to be %s, got %s", viewStateRelic, loaded.viewState)
+	}
+}
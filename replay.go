@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const replayFile = "last-game.json"
+
+// replay is everything needed to play a finished game back: the deal and
+// the moves made, as recorded in its events
+type replay struct {
+	Seed   int64
+	Rules  Rules
+	Events []event
+
+	// Whether the game was a campaign, and whether it carried the weapon
+	// down
+	Campaign    bool
+	CarryWeapon bool
+}
+
+func newReplay(m model) replay {
+	return replay{
+		Seed:   m.seed,
+		Rules:  m.rules,
+		Events: m.events,
+
+		Campaign:    m.inCampaign(),
+		CarryWeapon: m.campaign.carryWeapon,
+	}
+}
+
+func saveReplay(path string, r replay) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func loadReplay(path string) (replay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return replay{}, err
+	}
+
+	var r replay
+	if err := json.Unmarshal(data, &r); err != nil {
+		return replay{}, err
+	}
+	if r.Rules == (Rules{}) {
+		r.Rules = presets[defaultVariant]
+	}
+	return r, nil
+}
+
+// moveFor returns the move that produced an event, or false for events that
+// aren't moves
+func moveFor(e event) (move, bool) {
+	switch {
+	case e.Kind == eventPotion && isJoker(e.Card):
+		return move{card: e.Card, action: actionAsPotion}, true
+	case e.Kind == eventEquip && isJoker(e.Card):
+		return move{card: e.Card, action: actionAsWeapon}, true
+	}
+
+	switch e.Kind {
+	case eventPotion, eventEquip:
+		return move{card: e.Card, action: actionPlay}, true
+	case eventFlee:
+		return move{card: e.Card, action: actionFlee}, true
+	case eventFists:
+		return move{card: e.Card, action: actionFists}, true
+	case eventWeapon:
+		return move{card: e.Card, action: actionWeapon}, true
+	case eventSkip:
+		return move{action: actionSkip}, true
+	case eventTakeRelic:
+		return move{action: actionTake, relic: e.Relic}, true
+	default:
+		return move{}, false
+	}
+}
+
+// runReplay deals the game again and plays its moves, printing each one and
+// what it did
+func runReplay(out io.Writer, r replay, s settings) error {
+	s.confirmLethal = false
+	m := newGame(r.Seed, r.Rules, s)
+	if r.Campaign {
+		m.campaign = campaign{depth: 1, carryWeapon: r.CarryWeapon}
+	}
+
+	fmt.Fprintf(out, "Seed %d\n", r.Seed)
+	for _, e := range r.Events {
+		mv, ok := moveFor(e)
+		if !ok {
+			continue
+		}
+
+		fmt.Fprintln(out, m.describeState())
+		command := m.commandFor(mv)
+		fmt.Fprintf(out, "> %s\n", command)
+
+		events := len(m.events)
+		if err := m.command(command); err != nil {
+			return fmt.Errorf("replay doesn't match the deal: %w", err)
+		}
+		fmt.Fprintln(out, m.announce(m.events[events:]))
+	}
+
+	if m.viewState == viewStateGameOver {
+		fmt.Fprintln(out, m.gameOverSummary())
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+// Rules are the numbers and choices a game of Scoundrel is played by. They
+// are exported so saves and replays keep the rules their game was played
+// with.
+type Rules struct {
+	// Life at the start, and the most potions can heal to
+	StartLife int
+	MaxLife   int
+
+	// What an Ace counts as when fighting
+	AceValue int
+
+	// Cards dealt to a room, and how few are left before it's dealt again
+	RoomSize int
+	RefillAt int
+
+	// A used weapon can only hit monsters weaker than the last it slew,
+	// rather than weaker or as strong
+	StrictWeapon bool
+
+	// Jokers shuffled into the dungeon, and what they're worth when drunk
+	// as a potion or wielded as a weapon
+	Jokers     int
+	JokerValue int
+
+	// The cards the dungeon is dealt from, the zero value for the standard
+	// dungeon
+	Deck Composition
+
+	// How the dungeon is shuffled, the zero value for a uniform shuffle
+	Shuffle Shuffle
+}
+
+const defaultVariant = "official"
+
+// presets are the named variants that can be played
+var presets = map[string]Rules{
+	"official": {StartLife: 20, MaxLife: 20, AceValue: 14, RoomSize: 4, RefillAt: 1},
+
+	// Bigger rooms leaving two cards behind give more choice
+	"house": {StartLife: 20, MaxLife: 20, AceValue: 14, RoomSize: 5, RefillAt: 2},
+
+	"easy": {StartLife: 25, MaxLife: 25, AceValue: 11, RoomSize: 4, RefillAt: 1},
+	"hard": {StartLife: 15, MaxLife: 15, AceValue: 14, RoomSize: 4, RefillAt: 1, StrictWeapon: true},
+
+	// Two wild jokers, each played as a potion, a weapon or a way out of
+	// the room
+	"jokers": {StartLife: 20, MaxLife: 20, AceValue: 14, RoomSize: 4, RefillAt: 1, Jokers: 2, JokerValue: 6},
+}
+
+// variantNames lists the presets in a stable order
+func variantNames() []string {
+	var names []string
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Weapon degradation settings. Left empty the preset decides.
+const (
+	strictDegradation  = "strict"
+	lenientDegradation = "lenient"
+)
+
+// rules returns the rules to play by, the chosen preset with the starting
+// life and weapon degradation overridden if they were given
+func (s settings) rules() Rules {
+	r, ok := presets[s.variant]
+	if !ok {
+		r = presets[defaultVariant]
+	}
+	if s.startLife > 0 {
+		r.StartLife = s.startLife
+		r.MaxLife = max(r.MaxLife, s.startLife)
+	}
+	switch s.degradation {
+	case strictDegradation:
+		r.StrictWeapon = true
+	case lenientDegradation:
+		r.StrictWeapon = false
+	}
+	if s.deck.composition != (Composition{}) {
+		r.Deck = s.deck.composition
+		if s.deck.jokers >= 0 {
+			r.Jokers = s.deck.jokers
+			r.JokerValue = s.deck.jokerValue
+		}
+	}
+	r.Shuffle = s.shuffle
+	return r
+}
+
+// String names the rules by the preset they're closest to, noting anything
+// changed from it
+func (r Rules) String() string {
+	return r.describe(r.Deck.Name + " deck")
+}
+
+// key names the rules statistics are kept separately for. It's String with
+// a custom deck named by its cards rather than its name, so two decks with
+// the same name, or a deck file edited since, aren't mixed up.
+func (r Rules) key() string {
+	return r.describe("deck " + r.Deck.code())
+}
+
+func (r Rules) describe(deckName string) string {
+	s := r.presetString()
+	if r.Deck != (Composition{}) {
+		s += ", " + deckName
+	}
+	if r.Shuffle != (Shuffle{}) {
+		s += ", " + r.Shuffle.String()
+	}
+	return s
+}
+
+func (r Rules) presetString() string {
+	best, bestChanges := "", 0
+	for _, name := range variantNames() {
+		p := presets[name]
+		if p.AceValue != r.AceValue || p.RoomSize != r.RoomSize || p.RefillAt != r.RefillAt || max(p.MaxLife, r.StartLife) != r.MaxLife {
+			continue
+		}
+
+		// A different starting life counts for more than a different
+		// degradation, so the official rules with strict weapons aren't
+		// called hard with 20 life
+		s, changes := name, 0
+		if p.StartLife != r.StartLife {
+			s += fmt.Sprintf(", %d life", r.StartLife)
+			changes += 2
+		}
+		if p.Jokers != r.Jokers || (r.Jokers > 0 && p.JokerValue != r.JokerValue) {
+			s += ", " + r.jokersText()
+			changes += 2
+		}
+		if p.StrictWeapon != r.StrictWeapon {
+			s += ", " + r.degradation() + " weapons"
+			changes++
+		}
+		// Ties go to the official rules
+		if best == "" || changes < bestChanges || (changes == bestChanges && name == defaultVariant) {
+			best, bestChanges = s, changes
+		}
+	}
+	if best != "" {
+		return best
+	}
+	return fmt.Sprintf("custom (%d life, Ace %d, rooms of %d, %s weapons)", r.StartLife, r.AceValue, r.RoomSize, r.degradation())
+}
+
+// jokersText states the jokers in the dungeon
+func (r Rules) jokersText() string {
+	switch r.Jokers {
+	case 0:
+		return "no jokers"
+	case 1:
+		return fmt.Sprintf("1 joker of %d", r.JokerValue)
+	}
+	return fmt.Sprintf("%d jokers of %d", r.Jokers, r.JokerValue)
+}
+
+// degradation names how a used weapon weakens
+func (r Rules) degradation() string {
+	if r.StrictWeapon {
+		return strictDegradation
+	}
+	return lenientDegradation
+}
+
+// strength is how hard a card hits, and what it's worth as a penalty.
+// Jokers never hit.
+func (r Rules) strength(c deck.Card) int {
+	switch {
+	case isJoker(c):
+		return 0
+	case c.Rank == deck.Ace:
+		return r.AceValue
+	}
+	return int(c.Rank)
+}
+
+// value is what a card is worth when played, the healing of a potion, the
+// power of a weapon or the strength of a monster
+func (r Rules) value(c deck.Card) int {
+	if isJoker(c) {
+		return r.JokerValue
+	}
+	return r.strength(c)
+}
+
+// limitText states which monsters a weapon last used on a monster of
+// strength limit can still hit
+func (r Rules) limitText(limit int) string {
+	if r.StrictWeapon {
+		return fmt.Sprintf("monsters below strength %d", limit)
+	}
+	return fmt.Sprintf("monsters of strength %d or less", limit)
+}
+
+// canHit reports whether a weapon last used on a monster of strength limit
+// can hit one of the given strength
+func (r Rules) canHit(strength int, limit int) bool {
+	if r.StrictWeapon {
+		return strength < limit
+	}
+	return strength <= limit
+}
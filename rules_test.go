@@ -0,0 +1,133 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+func TestRulesStrength(t *testing.T) {
+	tests := []struct {
+		rules    Rules
+		card     deck.Card
+		expected int
+	}{
+		{presets["official"], deck.Card{Suit: deck.Spade, Rank: deck.Ace}, 14},
+		{presets["easy"], deck.Card{Suit: deck.Spade, Rank: deck.Ace}, 11},
+		{presets["easy"], deck.Card{Suit: deck.Spade, Rank: deck.King}, 13},
+	}
+
+	for _, test := range tests {
+		if got := test.rules.strength(test.card); got != test.expected {
+			t.Errorf("expected strength of %s to be %d, got %d", test.card, test.expected, got)
+		}
+	}
+}
+
+func TestRulesCanHit(t *testing.T) {
+	tests := []struct {
+		strict   bool
+		strength int
+		limit    int
+		expected bool
+	}{
+		{false, 5, 6, true},
+		{false, 6, 6, true},
+		{false, 7, 6, false},
+		{true, 5, 6, true},
+		{true, 6, 6, false},
+	}
+
+	for _, test := range tests {
+		r := Rules{StrictWeapon: test.strict}
+		if got := r.canHit(test.strength, test.limit); got != test.expected {
+			t.Errorf("expected %d against %d with strict %t to be %t, got %t", test.strength, test.limit, test.strict, test.expected, got)
+		}
+	}
+}
+
+func TestSettingsRules(t *testing.T) {
+	tests := []struct {
+		s         settings
+		startLife int
+		maxLife   int
+	}{
+		{settings{variant: "official"}, 20, 20},
+		{settings{variant: "hard"}, 15, 15},
+		{settings{variant: "unknown"}, 20, 20},
+		{settings{variant: "official", startLife: 10}, 10, 20},
+		{settings{variant: "official", startLife: 30}, 30, 30},
+	}
+
+	for _, test := range tests {
+		r := test.s.rules()
+		if r.StartLife != test.startLife || r.MaxLife != test.maxLife {
+			t.Errorf("expected %+v to start on %d of %d life, got %d of %d", test.s, test.startLife, test.maxLife, r.StartLife, r.MaxLife)
+		}
+	}
+}
+
+func TestHouseRoom(t *testing.T) {
+	m := newGame(1, presets["house"], settings{})
+	if len(m.room) != 5 {
+		t.Fatalf("expected room to have 5 cards, got %d", len(m.room))
+	}
+
+	// Playing down to two cards deals three more
+	dungeon := len(m.dungeon)
+	for range 3 {
+		m.selection = 0
+		m.discard()
+	}
+	if len(m.room) != 5 {
+		t.Errorf("expected room to have 5 cards, got %d", len(m.room))
+	}
+	if len(m.dungeon) != dungeon-3 {
+		t.Errorf("expected dungeon to have %d cards, got %d", dungeon-3, len(m.dungeon))
+	}
+	if !m.skippable {
+		t.Errorf("expected the new room to be skippable")
+	}
+}
+
+func TestStrictWeapon(t *testing.T) {
+	m := model{
+		rules: presets["hard"],
+		weapon: weapon{
+			card:  deck.Card{Suit: deck.Diamond, Rank: 8},
+			slain: []deck.Card{{Suit: deck.Spade, Rank: 6}},
+		},
+	}
+	if m.canUseWeapon(deck.Card{Suit: deck.Club, Rank: 6}) {
+		t.Errorf("expected a strict weapon not to hit a monster as strong as the last slain")
+	}
+	if !m.canUseWeapon(deck.Card{Suit: deck.Club, Rank: 5}) {
+		t.Errorf("expected a strict weapon to hit a weaker monster")
+	}
+}
+
+func TestRulesString(t *testing.T) {
+	tests := []struct {
+		s        settings
+		expected string
+	}{
+		{settings{variant: "official"}, "official"},
+		{settings{variant: "hard"}, "hard"},
+		{settings{variant: "official", degradation: strictDegradation}, "official, strict weapons"},
+		{settings{variant: "hard", degradation: lenientDegradation}, "hard, lenient weapons"},
+		{settings{variant: "official", startLife: 30}, "official, 30 life"},
+		{settings{variant: "hard", startLife: 10}, "hard, 10 life"},
+		{settings{variant: "official", shuffle: Shuffle{Kind: riffleShuffle, Times: 3}}, "official, 3 riffles"},
+	}
+
+	for _, test := range tests {
+		if got := test.s.rules().String(); got != test.expected {
+			t.Errorf("expected rules to be %q, got %q", test.expected, got)
+		}
+	}
+
+	custom := Rules{StartLife: 20, MaxLife: 20, AceValue: 1, RoomSize: 3, RefillAt: 1}
+	if got := custom.String(); got != "custom (20 life, Ace 1, rooms of 3, lenient weapons)" {
+		t.Errorf("expected custom rules to be described, got %q", got)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+const saveFile = "save.json"
+
+// savedGame is the on-disk form of an unfinished game
+type savedGame struct {
+	Seed      int64
+	Dungeon   []deck.Card
+	Room      []deck.Card
+	Discarded []deck.Card
+	Life      int
+	Weapon    deck.Card
+	Slain     []deck.Card
+	Skippable bool
+	LastCard  deck.Card
+	Events    []event
+	Rules     Rules
+
+	// Campaign progress, a depth of 0 for a single game
+	Depth       int
+	CarryWeapon bool
+
+	// Relics held and on offer, and potions drunk in the room
+	Relics      []string
+	RelicOffers []string
+	RoomPotions int
+
+	// The bundled puzzle being played, if any
+	Puzzle string
+}
+
+func saveGame(dir string, m model) error {
+	data, err := json.Marshal(savedGame{
+		Seed:      m.seed,
+		Dungeon:   m.dungeon,
+		Room:      m.room,
+		Discarded: m.discarded,
+		Life:      m.life,
+		Weapon:    m.weapon.card,
+		Slain:     m.weapon.slain,
+		Skippable: m.skippable,
+		LastCard:  m.lastCard,
+		Events:    m.events,
+		Rules:     m.rules,
+
+		Depth:       m.campaign.depth,
+		CarryWeapon: m.campaign.carryWeapon,
+
+		Relics:      m.relics,
+		RelicOffers: m.relicOffers,
+		RoomPotions: m.roomPotions,
+
+		Puzzle: puzzleName(m),
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, saveFile), data, 0o644)
+}
+
+func loadGame(dir string, s settings) (model, error) {
+	data, err := os.ReadFile(filepath.Join(dir, saveFile))
+	if err != nil {
+		return model{}, err
+	}
+
+	var g savedGame
+	if err := json.Unmarshal(data, &g); err != nil {
+		return model{}, err
+	}
+
+	// Saves from before rules were recorded were played by the official ones
+	if g.Rules == (Rules{}) {
+		g.Rules = presets[defaultVariant]
+	}
+
+	m := newGame(g.Seed, g.Rules, s)
+	m.dungeon = g.Dungeon
+	m.room = g.Room
+	m.discarded = g.Discarded
+	m.life = g.Life
+	m.weapon = weapon{card: g.Weapon, slain: g.Slain}
+	m.skippable = g.Skippable
+	m.lastCard = g.LastCard
+	m.events = g.Events
+	m.campaign = campaign{depth: g.Depth, carryWeapon: g.CarryWeapon}
+	m.relics = g.Relics
+	m.relicOffers = g.RelicOffers
+	m.roomPotions = g.RoomPotions
+	if len(m.relicOffers) > 0 {
+		m.viewState = viewStateRelic
+	}
+	if g.Puzzle != "" {
+		if m.puzzle, err = findPuzzle(g.Puzzle); err != nil {
+			return model{}, err
+		}
+	}
+	m.shownLife = m.life
+	m.dealt = len(m.room)
+	return m, nil
+}
+
+func puzzleName(m model) string {
+	if !m.inPuzzle() {
+		return ""
+	}
+	return m.puzzle.name
+}
+
+func hasSave(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, saveFile))
+	return err == nil
+}
+
+func deleteSave(dir string) error {
+	err := os.Remove(filepath.Join(dir, saveFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
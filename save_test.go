@@ -0,0 +1,95 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+func TestSaveAndLoadGame(t *testing.T) {
+	dir := t.TempDir()
+	s := settings{dataDir: dir}
+
+	if hasSave(dir) {
+		t.Fatal("expected no save in an empty directory")
+	}
+
+	m := newGame(7, s.rules(), s)
+	m.life = 13
+	m.weapon = weapon{
+		card:  deck.Card{Suit: deck.Diamond, Rank: 6},
+		slain: []deck.Card{{Suit: deck.Club, Rank: deck.Queen}},
+	}
+	m.discard()
+
+	if err := saveGame(dir, m); err != nil {
+		t.Fatal(err)
+	}
+	if !hasSave(dir) {
+		t.Fatal("expected a save after saving")
+	}
+
+	loaded, err := loadGame(dir, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.seed != m.seed {
+		t.Errorf("expected seed to be %d, got %d", m.seed, loaded.seed)
+	}
+	assertExpectedLife(t, loaded.life, m.life)
+	assertExpectedSkippable(t, loaded.skippable, m.skippable)
+	assertLastCard(t, loaded.lastCard, m.lastCard)
+	for name, cards := range map[string][2][]deck.Card{
+		"dungeon":   {m.dungeon, loaded.dungeon},
+		"room":      {m.room, loaded.room},
+		"discarded": {m.discarded, loaded.discarded},
+		"slain":     {m.weapon.slain, loaded.weapon.slain},
+	} {
+		if !reflect.DeepEqual(cards[0], cards[1]) {
+			t.Errorf("expected %s to be %v, got %v", name, cards[0], cards[1])
+		}
+	}
+
+	if err := deleteSave(dir); err != nil {
+		t.Fatal(err)
+	}
+	if hasSave(dir) {
+		t.Error("expected no save after deleting it")
+	}
+	if err := deleteSave(dir); err != nil {
+		t.Errorf("expected deleting a missing save to succeed, got %v", err)
+	}
+}
+
+// A save that can't be deleted mustn't stop the game being recorded
+func TestRecordWhenSaveStays(t *testing.T) {
+	dir := t.TempDir()
+	s := settings{dataDir: dir}
+
+	// A directory in the way of the save can't be removed like a file
+	if err := os.MkdirAll(filepath.Join(dir, saveFile, "stuck"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	m := newGame(7, s.rules(), s)
+	m.life = 0
+	m.viewState = viewStateGameOver
+	msg := m.record()().(gameRecordedMsg)
+	if msg.err == nil {
+		t.Error("expected the save not being deleted to be reported")
+	}
+
+	b, err := loadStats(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := b[m.rules.key()]; s.Played != 1 {
+		t.Errorf("expected the game to be recorded, got %+v", b)
+	}
+	if _, err := loadReplay(filepath.Join(dir, replayFile)); err != nil {
+		t.Errorf("expected the replay to be written, got %v", err)
+	}
+}
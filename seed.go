@@ -0,0 +1,74 @@
+package main
+
+import (
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// seedModel is the screen for entering the seed of a game to play
+type seedModel struct {
+	settings settings
+	input    string
+	err      error
+
+	// Terminal dimensions
+	width  int
+	height int
+}
+
+func newSeedInput(s settings) seedModel {
+	return seedModel{settings: s}
+}
+
+func (m seedModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m seedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			return m, tea.Quit
+		case tea.KeyEsc:
+			return m, switchTo(newMenu(m.settings))
+		case tea.KeyBackspace:
+			if len(m.input) > 0 {
+				m.input = m.input[:len(m.input)-1]
+			}
+		case tea.KeyEnter:
+			seed, err := strconv.ParseInt(m.input, 10, 64)
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			return m, switchTo(newGame(seed, m.settings.rules(), m.settings))
+		case tea.KeyRunes:
+			for _, r := range msg.Runes {
+				if (r >= '0' && r <= '9') || (r == '-' && len(m.input) == 0) {
+					m.input += string(r)
+				}
+			}
+			m.err = nil
+		}
+	}
+
+	return m, nil
+}
+
+func (m seedModel) View() string {
+	g := m.settings.glyphs()
+	s := g.seedTitle + "\n\n"
+	s += "Seed: " + m.input + g.cursor + "\n"
+	if m.err != nil {
+		s += "\n" + m.settings.styles().danger.Render("That isn't a valid seed.") + "\n"
+	}
+	s += "\nPress enter to play. Press esc to go back."
+
+	return placeView(s, m.width, m.height)
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type settings struct {
+	showCounter   bool
+	confirmLethal bool
+	animations    bool
+	theme         string
+	accessible    bool
+	ascii         bool
+	variant       string
+	startLife     int
+	degradation   string
+	carryWeapon   bool
+	dataDir       string
+	keys          keymap
+
+	// A dungeon read from a deck definition file, if one was given
+	deck deckFile
+
+	// How the dungeon is shuffled
+	shuffle Shuffle
+}
+
+func defaultSettings() settings {
+	return settings{
+		showCounter:   false,
+		confirmLethal: true,
+		animations:    true,
+		theme:         defaultTheme,
+		variant:       defaultVariant,
+		dataDir:       defaultDataDir(),
+		keys:          defaultKeymap(),
+	}
+}
+
+// defaultDataDir is where saves and statistics are kept, following the XDG
+// base directory spec
+func defaultDataDir() string {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "scoundrel")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "."
+	}
+	return filepath.Join(home, ".local", "share", "scoundrel")
+}
+
+// settingsModel is the screen for toggling settings
+type settingsModel struct {
+	settings  settings
+	selection int
+
+	// Terminal dimensions
+	width  int
+	height int
+}
+
+// setting is an option on the settings screen
+type setting struct {
+	label  string
+	value  func(s settings) string
+	change func(s *settings)
+}
+
+// toggle is a setting that is either on or off
+func toggle(label string, field func(s *settings) *bool) setting {
+	return setting{
+		label: label,
+		value: func(s settings) string {
+			if *field(&s) {
+				return "on"
+			}
+			return "off"
+		},
+		change: func(s *settings) {
+			*field(s) = !*field(s)
+		},
+	}
+}
+
+var settingsOptions = []setting{
+	toggle("Show card counter", func(s *settings) *bool { return &s.showCounter }),
+	toggle("Confirm lethal moves", func(s *settings) *bool { return &s.confirmLethal }),
+	toggle("Animations", func(s *settings) *bool { return &s.animations }),
+	toggle("Campaign keeps the weapon", func(s *settings) *bool { return &s.carryWeapon }),
+	{
+		label:  "Theme",
+		value:  func(s settings) string { return s.theme },
+		change: func(s *settings) { s.theme = nextTheme(s.theme) },
+	},
+	{
+		label:  "Rules",
+		value:  func(s settings) string { return s.variant },
+		change: func(s *settings) { s.variant = cycle(variantNames(), s.variant) },
+	},
+	{
+		label: "Weapon degradation",
+		value: func(s settings) string {
+			if s.degradation == "" {
+				return "as the rules say (" + s.rules().degradation() + ")"
+			}
+			return s.degradation
+		},
+		change: func(s *settings) {
+			s.degradation = cycle([]string{"", strictDegradation, lenientDegradation}, s.degradation)
+		},
+	},
+	{
+		label: "Shuffle",
+		value: func(s settings) string { return s.shuffle.String() },
+		change: func(s *settings) {
+			next := shuffleChoices[0]
+			for i, c := range shuffleChoices {
+				if c == s.shuffle {
+					next = shuffleChoices[(i+1)%len(shuffleChoices)]
+				}
+			}
+			s.shuffle = next
+		},
+	},
+}
+
+// cycle returns the name after the given one, wrapping around to the first
+func cycle(names []string, name string) string {
+	for i, n := range names {
+		if n == name {
+			return names[(i+1)%len(names)]
+		}
+	}
+	return names[0]
+}
+
+func newSettingsScreen(s settings) settingsModel {
+	return settingsModel{settings: s}
+}
+
+func (m settingsModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m settingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+
+		action := m.settings.keys.action(msg.String())
+		// Space toggles as well as the choose keys
+		if msg.String() == " " {
+			action = keyChoose
+		}
+		switch action {
+		case keyBack:
+			return m, switchTo(newMenu(m.settings))
+		case keyUp:
+			m.selection = (m.selection - 1 + len(settingsOptions)) % len(settingsOptions)
+		case keyDown:
+			m.selection = (m.selection + 1) % len(settingsOptions)
+		case keyChoose:
+			settingsOptions[m.selection].change(&m.settings)
+		}
+	}
+
+	return m, nil
+}
+
+func (m settingsModel) View() string {
+	s := m.settings.glyphs().settingsTitle + "\n\n"
+	for i, o := range settingsOptions {
+		cursor := " "
+		if m.selection == i {
+			cursor = ">"
+		}
+		s += fmt.Sprintf("%s %s: %s\n", cursor, o.label, o.value(m.settings))
+	}
+	s += "\nPress enter to change. Press q to go back."
+
+	return placeView(s, m.width, m.height)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+// Shuffle is how the dungeon is shuffled before it's dealt. The zero value
+// shuffles uniformly, the others shuffle the way a person would, leaving
+// some of the unshuffled order in clumps.
+type Shuffle struct {
+	// riffleShuffle or overhandShuffle, empty for a uniform shuffle
+	Kind string
+
+	// How many times the cards are shuffled
+	Times int
+}
+
+const (
+	uniformShuffle  = "uniform"
+	riffleShuffle   = "riffle"
+	overhandShuffle = "overhand"
+)
+
+// defaultShuffles is how many times each kind of shuffle is done when it
+// isn't said. Seven riffles are about enough to mix a deck, an overhand
+// shuffle needs many more.
+var defaultShuffles = map[string]int{
+	riffleShuffle:   7,
+	overhandShuffle: 50,
+}
+
+// shuffleChoices are the shuffles offered on the settings screen
+var shuffleChoices = []Shuffle{
+	{},
+	{Kind: riffleShuffle, Times: 3},
+	{Kind: riffleShuffle, Times: 7},
+	{Kind: overhandShuffle, Times: 10},
+}
+
+// parseShuffle reads a shuffle like "uniform", "riffle" or "3 riffles"
+func parseShuffle(s string) (Shuffle, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" || s == uniformShuffle {
+		return Shuffle{}, nil
+	}
+
+	times, kind, counted := strings.Cut(s, " ")
+	if !counted {
+		kind = times
+	}
+	kind = strings.TrimSuffix(strings.TrimSpace(kind), "s")
+	if _, ok := defaultShuffles[kind]; !ok {
+		return Shuffle{}, fmt.Errorf("unknown shuffle %q, choose uniform, riffle or overhand, with how many like 3 riffles", s)
+	}
+	if !counted {
+		return Shuffle{Kind: kind, Times: defaultShuffles[kind]}, nil
+	}
+
+	n, err := strconv.Atoi(times)
+	if err != nil || n < 1 {
+		return Shuffle{}, fmt.Errorf("can't shuffle %q times", times)
+	}
+	return Shuffle{Kind: kind, Times: n}, nil
+}
+
+// String writes the shuffle the way parseShuffle reads it
+func (s Shuffle) String() string {
+	switch {
+	case s.Kind == "":
+		return uniformShuffle
+	case s.Times == 1:
+		return "1 " + s.Kind
+	}
+	return fmt.Sprintf("%d %ss", s.Times, s.Kind)
+}
+
+// shuffle shuffles the cards by seed. Shuffling by hand ends with a cut, as
+// it would at the table.
+func (s Shuffle) shuffle(seed int64) func([]deck.Card) []deck.Card {
+	var shuffle func([]deck.Card) []deck.Card
+	switch s.Kind {
+	case riffleShuffle:
+		shuffle = deck.Riffle(s.Times, seed)
+	case overhandShuffle:
+		shuffle = deck.Overhand(s.Times, seed)
+	default:
+		return deck.SeededShuffle(seed)
+	}
+	return func(cards []deck.Card) []deck.Card {
+		return deck.Cut(seed + 1)(shuffle(cards))
+	}
+}
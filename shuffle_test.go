@@ -0,0 +1,58 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+func TestParseShuffle(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected Shuffle
+	}{
+		{"", Shuffle{}},
+		{"uniform", Shuffle{}},
+		{"riffle", Shuffle{Kind: riffleShuffle, Times: 7}},
+		{"3 riffles", Shuffle{Kind: riffleShuffle, Times: 3}},
+		{"1 Riffle", Shuffle{Kind: riffleShuffle, Times: 1}},
+		{"10 overhands", Shuffle{Kind: overhandShuffle, Times: 10}},
+	}
+
+	for _, test := range tests {
+		got, err := parseShuffle(test.s)
+		if err != nil || got != test.expected {
+			t.Errorf("expected %q to be %+v, got %+v (%v)", test.s, test.expected, got, err)
+		}
+		if again, _ := parseShuffle(got.String()); again != got {
+			t.Errorf("expected %q to read back as %+v, got %+v", got, got, again)
+		}
+	}
+
+	for _, s := range []string{"shuffle", "0 riffles", "three riffles"} {
+		if _, err := parseShuffle(s); err == nil {
+			t.Errorf("expected %q not to parse", s)
+		}
+	}
+}
+
+func TestShuffledDungeon(t *testing.T) {
+	uniform := newDungeon(1, presets["official"])
+	for _, shuffle := range shuffleChoices[1:] {
+		r := presets["official"]
+		r.Shuffle = shuffle
+
+		d := newDungeon(1, r)
+		if slices.Equal(d, uniform) {
+			t.Errorf("expected %s to deal another dungeon", shuffle)
+		}
+		if !slices.Equal(d, newDungeon(1, r)) {
+			t.Errorf("expected %s to deal the same dungeon from the same seed", shuffle)
+		}
+		deck.DefaultSort(d)
+		if !slices.Equal(d, r.composition().cards()) {
+			t.Errorf("expected %s to deal the same cards, got %v", shuffle, d)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+// solver searches every line of play for the best score, remembering the
+// positions it has already scored
+type solver struct {
+	best   map[string]solution
+	nodes  int
+	budget int
+
+	// complete is false once the budget ran out and some positions were
+	// only scored by letting the bot play them out
+	complete bool
+}
+
+type solution struct {
+	score int
+	move  move
+}
+
+// solve finds the best score reachable from the position and the moves that
+// reach it, searching at most budget positions
+func solve(m model, budget int) (int, []string, bool) {
+	m.settings.confirmLethal = false
+	m.settings.animations = false
+	m.events = nil
+
+	s := solver{best: map[string]solution{}, budget: budget, complete: true}
+	score := s.search(m)
+
+	// Follow the best moves from the start to recover the line
+	var moves []string
+	for m.viewState != viewStateGameOver {
+		sol, ok := s.best[m.key()]
+		if !ok {
+			break
+		}
+		command := m.commandFor(sol.move)
+		moves = append(moves, command)
+		if err := m.command(command); err != nil {
+			break
+		}
+	}
+	return score, moves, s.complete
+}
+
+func (s *solver) search(m model) int {
+	if m.viewState == viewStateGameOver {
+		return m.score()
+	}
+
+	key := m.key()
+	if sol, ok := s.best[key]; ok {
+		return sol.score
+	}
+
+	s.nodes++
+	if s.nodes > s.budget {
+		s.complete = false
+		return s.playout(m, key)
+	}
+
+	// Only moves that play count, so a first move that can't be played
+	// doesn't leave a best of nothing scoring 0
+	var best solution
+	found := false
+	for _, mv := range m.legalMoves() {
+		next := m.clone()
+		if err := next.play(mv); err != nil {
+			continue
+		}
+		next.events = nil
+		score := s.search(next)
+		if !found || score > best.score {
+			best, found = solution{score, mv}, true
+		}
+	}
+	if !found {
+		return m.score()
+	}
+	s.best[key] = best
+	return best.score
+}
+
+// playout scores a position by letting the bot play it to the end
+func (s *solver) playout(m model, key string) int {
+	first := botMove(m)
+	m = m.clone()
+	if err := m.play(first); err != nil {
+		return m.score()
+	}
+	for m.viewState != viewStateGameOver {
+		if err := m.play(botMove(m)); err != nil {
+			break
+		}
+	}
+	s.best[key] = solution{m.score(), first}
+	return m.score()
+}
+
+// key identifies a position by everything that affects how it can play out.
+// The room is sorted as the order of its cards doesn't matter.
+func (m model) key() string {
+	room := slices.Clone(m.room)
+	slices.SortFunc(room, func(a, b deck.Card) int {
+		return int(a.Suit)*100 + int(a.Rank) - int(b.Suit)*100 - int(b.Rank)
+	})
+
+	limit, _ := m.weaponLimit()
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d|%d|%d|%t|", m.life, m.weaponPower(), limit, m.skippable)
+	for _, c := range room {
+		fmt.Fprintf(&b, "%d%d,", c.Suit, c.Rank)
+	}
+	b.WriteString("|")
+	for _, c := range m.dungeon {
+		fmt.Fprintf(&b, "%d%d,", c.Suit, c.Rank)
+	}
+	return b.String()
+}
+
+// solvePuzzle searches for a line of play that reaches the puzzle's goal,
+// returning its moves or false if there's none
+func solvePuzzle(m model) ([]string, bool) {
+	m.settings.confirmLethal = false
+	m.settings.animations = false
+
+	// Positions that can't reach the goal, kept so they're only searched once
+	failed := map[string]bool{}
+
+	var search func(m model) ([]string, bool)
+	search = func(m model) ([]string, bool) {
+		if m.viewState == viewStateGameOver {
+			return nil, m.solved()
+		}
+		key := m.key()
+		if failed[key] {
+			return nil, false
+		}
+		for _, mv := range m.legalMoves() {
+			next := m.clone()
+			command := next.commandFor(mv)
+			if err := next.command(command); err != nil {
+				continue
+			}
+			if moves, ok := search(next); ok {
+				return append([]string{command}, moves...), true
+			}
+		}
+		failed[key] = true
+		return nil, false
+	}
+	return search(m)
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const statsFile = "stats.json"
+
+// recentGames is how many of the latest scores are kept
+const recentGames = 10
+
+type stats struct {
+	// The rules as they're shown, which may name a deck the key doesn't
+	Rules string `json:",omitempty"`
+
+	Played int
+	Won    int
+	Best   int
+	Total  int
+	Recent []int
+}
+
+func (s *stats) record(score int, won bool) {
+	if s.Played == 0 || score > s.Best {
+		s.Best = score
+	}
+	s.Played++
+	if won {
+		s.Won++
+	}
+	s.Total += score
+	s.Recent = append(s.Recent, score)
+	if len(s.Recent) > recentGames {
+		s.Recent = s.Recent[len(s.Recent)-recentGames:]
+	}
+}
+
+func (s stats) average() float64 {
+	if s.Played == 0 {
+		return 0
+	}
+	return float64(s.Total) / float64(s.Played)
+}
+
+// statsBook keeps statistics separately for each set of rules, named by
+// Rules.key, so scores under different rules aren't mixed
+type statsBook map[string]stats
+
+func loadStats(dir string) (statsBook, error) {
+	data, err := os.ReadFile(filepath.Join(dir, statsFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return statsBook{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var b statsBook
+	if err := json.Unmarshal(data, &b); err == nil {
+		return b, nil
+	}
+
+	// Statistics from before they were kept by rules were all played by the
+	// official ones
+	var s stats
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return statsBook{presets[defaultVariant].key(): s}, nil
+}
+
+func saveStats(dir string, s statsBook) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, statsFile), data, 0o644)
+}
+
+// recordGame adds a finished game to the statistics kept in dir for the
+// rules it was played by
+func recordGame(dir string, r Rules, score int, won bool) error {
+	b, err := loadStats(dir)
+	if err != nil {
+		return err
+	}
+	s := b[r.key()]
+	s.Rules = r.String()
+	s.record(score, won)
+	b[r.key()] = s
+	return saveStats(dir, b)
+}
+
+// statsModel is the screen showing the statistics
+type statsModel struct {
+	settings    settings
+	stats       statsBook
+	leaderboard []campaignEntry
+	err         error
+
+	// Terminal dimensions
+	width  int
+	height int
+}
+
+func showStats(s settings) tea.Cmd {
+	return func() tea.Msg {
+		st, err := loadStats(s.dataDir)
+		if err != nil {
+			return switchScreenMsg{statsModel{settings: s, err: err}}
+		}
+		leaderboard, err := loadLeaderboard(s.dataDir)
+		return switchScreenMsg{statsModel{settings: s, stats: st, leaderboard: leaderboard, err: err}}
+	}
+}
+
+func (m statsModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m statsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		default:
+			return m, switchTo(newMenu(m.settings))
+		}
+	}
+
+	return m, nil
+}
+
+func (m statsModel) View() string {
+	s := m.settings.glyphs().statsTitle + "\n\n" + m.summary()
+	s += "\n\nPress any key to go back."
+
+	return placeView(s, m.width, m.height)
+}
+
+// summary lists the statistics for each set of rules played, starting
+// with the rules currently chosen
+func (m statsModel) summary() string {
+	if m.err != nil {
+		return m.settings.styles().danger.Render(fmt.Sprintf("Couldn't load statistics: %v", m.err))
+	}
+	if len(m.stats) == 0 && len(m.leaderboard) == 0 {
+		return "No games played yet."
+	}
+
+	current := m.settings.rules().key()
+	var names []string
+	for name := range m.stats {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if (names[i] == current) != (names[j] == current) {
+			return names[i] == current
+		}
+		return names[i] < names[j]
+	})
+
+	var sections []string
+	for _, name := range names {
+		s := m.stats[name]
+		if s.Rules != "" {
+			name = s.Rules
+		}
+		sections = append(sections, fmt.Sprintf("Rules:      %s\n%s", name, s.summary()))
+	}
+	if len(m.leaderboard) > 0 {
+		sections = append(sections, leaderboardView(m.leaderboard))
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+// summary lists the statistics one per line
+func (s stats) summary() string {
+	lines := []string{
+		fmt.Sprintf("Played:     %d", s.Played),
+		fmt.Sprintf("Won:        %d (%.0f%%)", s.Won, 100*float64(s.Won)/float64(max(1, s.Played))),
+		fmt.Sprintf("Best score: %d", s.Best),
+		fmt.Sprintf("Average:    %.1f", s.average()),
+	}
+
+	var recent []string
+	for _, score := range s.Recent {
+		recent = append(recent, fmt.Sprint(score))
+	}
+	lines = append(lines, fmt.Sprintf("Recent:     %s", strings.Join(recent, " ")))
+	return strings.Join(lines, "\n")
+}
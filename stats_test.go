@@ -0,0 +1,122 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStatsRecord(t *testing.T) {
+	var s stats
+	s.record(-40, false)
+	s.record(12, true)
+	s.record(-3, false)
+
+	if s.Played != 3 {
+		t.Errorf("expected played to be 3, got %d", s.Played)
+	}
+	if s.Won != 1 {
+		t.Errorf("expected won to be 1, got %d", s.Won)
+	}
+	if s.Best != 12 {
+		t.Errorf("expected best to be 12, got %d", s.Best)
+	}
+	if s.average() != float64(-40+12-3)/3 {
+		t.Errorf("expected average to be %f, got %f", float64(-40+12-3)/3, s.average())
+	}
+
+	for i := 0; i < recentGames; i++ {
+		s.record(i, true)
+	}
+	if len(s.Recent) != recentGames {
+		t.Errorf("expected %d recent scores, got %d", recentGames, len(s.Recent))
+	}
+	if s.Recent[0] != 0 {
+		t.Errorf("expected oldest recent score to be 0, got %d", s.Recent[0])
+	}
+}
+
+func TestRecordGame(t *testing.T) {
+	dir := t.TempDir()
+
+	strict := official
+	strict.StrictWeapon = true
+
+	if err := recordGame(dir, official, 5, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := recordGame(dir, official, -10, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := recordGame(dir, strict, 8, true); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := loadStats(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := b["official"]; s.Played != 2 || s.Won != 1 || s.Best != 5 || s.Total != -5 {
+		t.Errorf("expected 2 played, 1 won, best 5 and total -5, got %+v", s)
+	}
+	if s := b["official, strict weapons"]; s.Played != 1 || s.Best != 8 {
+		t.Errorf("expected 1 played with best 8 under strict weapons, got %+v", s)
+	}
+}
+
+func TestLoadOldStats(t *testing.T) {
+	dir := t.TempDir()
+	old := `{"Played":3,"Won":1,"Best":12,"Total":-31,"Recent":[-40,12,-3]}`
+	if err := os.WriteFile(filepath.Join(dir, statsFile), []byte(old), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := loadStats(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := b["official"]; s.Played != 3 || s.Best != 12 {
+		t.Errorf("expected old statistics to be kept under the official rules, got %+v", b)
+	}
+}
+
+func TestRecordGameCustomDecks(t *testing.T) {
+	dir := t.TempDir()
+
+	low, err := parseDeck(strings.NewReader(`diamonds = "2-7"`), "deck")
+	if err != nil {
+		t.Fatal(err)
+	}
+	high, err := parseDeck(strings.NewReader(`diamonds = "8-10"`), "deck")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lowRules := settings{variant: "official", deck: low}.rules()
+	highRules := settings{variant: "official", deck: high}.rules()
+	if lowRules.String() != highRules.String() {
+		t.Fatalf("expected decks with the same name to read the same, got %q and %q", lowRules, highRules)
+	}
+	if lowRules.key() == highRules.key() {
+		t.Fatalf("expected different decks to keep separate statistics, both are %q", lowRules.key())
+	}
+
+	if err := recordGame(dir, lowRules, 5, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := recordGame(dir, highRules, -3, false); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := loadStats(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := b[lowRules.key()]; s.Played != 1 || s.Best != 5 || s.Rules != "official, deck deck" {
+		t.Errorf("expected 1 game with best 5 shown as the deck's name, got %+v", s)
+	}
+	if s := b[highRules.key()]; s.Played != 1 || s.Best != -3 {
+		t.Errorf("expected 1 game with best -3, got %+v", s)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"os"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/andrewdaoust/scoundrel/deck"
+)
+
+// theme holds the styles the screens are drawn with
+type theme struct {
+	redSuit   lipgloss.Style
+	blackSuit lipgloss.Style
+	potion    lipgloss.Style
+	weapon    lipgloss.Style
+	monster   lipgloss.Style
+	danger    lipgloss.Style
+	flash     lipgloss.Style
+	disabled  lipgloss.Style
+	border    lipgloss.Style
+}
+
+const defaultTheme = "classic"
+
+func colourTheme(red, black, potion, weapon, danger lipgloss.Color) theme {
+	return theme{
+		redSuit:   lipgloss.NewStyle().Foreground(red),
+		blackSuit: lipgloss.NewStyle().Foreground(black),
+		potion:    lipgloss.NewStyle().Foreground(potion),
+		weapon:    lipgloss.NewStyle().Foreground(weapon),
+		monster:   lipgloss.NewStyle().Foreground(black),
+		danger:    lipgloss.NewStyle().Foreground(danger).Bold(true),
+		flash:     lipgloss.NewStyle().Foreground(danger).Reverse(true),
+		disabled:  lipgloss.NewStyle().Faint(true),
+		border:    lipgloss.NewStyle().BorderForeground(weapon),
+	}
+}
+
+var themes = map[string]theme{
+	"classic": colourTheme("9", "252", "10", "12", "9"),
+
+	// Blue and orange stay distinct for red-green colour blindness
+	"deuteranopia": colourTheme("208", "252", "39", "220", "208"),
+	"protanopia":   colourTheme("214", "252", "33", "229", "226"),
+
+	"high-contrast": func() theme {
+		t := colourTheme("196", "231", "46", "51", "196")
+		t.redSuit = t.redSuit.Bold(true)
+		t.blackSuit = t.blackSuit.Bold(true)
+		t.potion = t.potion.Bold(true)
+		t.weapon = t.weapon.Bold(true)
+		t.monster = t.monster.Bold(true)
+		t.danger = t.danger.Underline(true)
+		return t
+	}(),
+
+	// Without colour danger is still underlined and the flash reversed
+	"mono": {
+		danger:   lipgloss.NewStyle().Bold(true).Underline(true),
+		flash:    lipgloss.NewStyle().Reverse(true),
+		disabled: lipgloss.NewStyle().Faint(true),
+	},
+}
+
+// suit returns the style for cards of the suit
+func (t theme) suit(s deck.Suit) lipgloss.Style {
+	if s == deck.Heart || s == deck.Diamond {
+		return t.redSuit
+	}
+	return t.blackSuit
+}
+
+// themeNames lists the built-in themes in a stable order
+func themeNames() []string {
+	var names []string
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// styles returns the theme to draw with, which is always mono when NO_COLOR
+// is set
+func (s settings) styles() theme {
+	if os.Getenv("NO_COLOR") != "" {
+		return themes["mono"]
+	}
+	if t, ok := themes[s.theme]; ok {
+		return t
+	}
+	return themes[defaultTheme]
+}
+
+// nextTheme cycles through the built-in themes
+func nextTheme(name string) string {
+	return cycle(themeNames(), name)
+}
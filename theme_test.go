@@ -0,0 +1,36 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNextTheme(t *testing.T) {
+	names := themeNames()
+	name := names[0]
+	for i := 1; i <= len(names); i++ {
+		name = nextTheme(name)
+		if name != names[i%len(names)] {
+			t.Errorf("expected theme %d to be %s, got %s", i, names[i%len(names)], name)
+		}
+	}
+
+	if name := nextTheme("unknown"); name != names[0] {
+		t.Errorf("expected an unknown theme to cycle to %s, got %s", names[0], name)
+	}
+}
+
+func TestStyles(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	if got := (settings{theme: "high-contrast"}).styles(); !reflect.DeepEqual(got, themes["high-contrast"]) {
+		t.Error("expected the high-contrast theme to be used")
+	}
+	if got := (settings{theme: "unknown"}).styles(); !reflect.DeepEqual(got, themes[defaultTheme]) {
+		t.Errorf("expected an unknown theme to fall back to %s", defaultTheme)
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if got := (settings{theme: "high-contrast"}).styles(); !reflect.DeepEqual(got, themes["mono"]) {
+		t.Error("expected NO_COLOR to use the mono theme")
+	}
+}
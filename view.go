@@ -2,8 +2,12 @@ package main
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
 	"github.com/andrewdaoust/scoundrel/deck"
 )
 
@@ -13,6 +17,8 @@ const (
 	viewStateRoom viewState = "room"
 	// viewStateChooseAttack viewState = "choose"
 	viewStateAttack   viewState = "attack"
+	viewStateJoker    viewState = "joker"
+	viewStateRelic    viewState = "relic"
 	viewStateGameOver viewState = "gameover"
 )
 
@@ -24,15 +30,10 @@ func layoutView(header string, selectionLines []string, footer string, width int
 
 	var result []string
 
-	// Calculate header position (centered)
 	headerLine := strings.TrimSuffix(header, "\n\n")
-	headerPadding := (width - len(headerLine)) / 2
+	headerPadding, topPadding := layoutPadding(headerLine, selectionLines, footer, width, height)
 	centeredHeader := strings.Repeat(" ", headerPadding) + headerLine
 
-	// Add vertical padding to center the entire content block
-	totalContentLines := 1 + len(selectionLines) + strings.Count(footer, "\n") + 1 // +1 for spacing
-	topPadding := (height - totalContentLines) / 2
-
 	// Add top padding
 	for i := 0; i < topPadding; i++ {
 		result = append(result, "")
@@ -65,6 +66,38 @@ func layoutView(header string, selectionLines []string, footer string, width int
 	return strings.Join(result, "\n")
 }
 
+// layoutPadding calculates the horizontal padding of the header and the
+// vertical padding above it used by layoutView
+func layoutPadding(header string, selectionLines []string, footer string, width int, height int) (int, int) {
+	// Calculate header position (centered)
+	headerPadding := (width - len(ansi.Strip(header))) / 2
+
+	// Add vertical padding to center the entire content block
+	totalContentLines := 1 + len(selectionLines) + strings.Count(footer, "\n") + 1 // +1 for spacing
+	topPadding := (height - totalContentLines) / 2
+
+	return headerPadding, topPadding
+}
+
+// selectionAt returns the index of the selection line rendered by layoutView
+// at the given terminal cell, or -1 if there is none
+func selectionAt(header string, selectionLines []string, footer string, width int, height int, x int, y int) int {
+	if width <= 0 || height <= 0 {
+		return -1
+	}
+
+	headerPadding, topPadding := layoutPadding(strings.TrimSuffix(header, "\n\n"), selectionLines, footer, width, height)
+	left := headerPadding + 2
+	i := y - max(0, topPadding) - 2 // header and the empty line after it
+	if i < 0 || i >= len(selectionLines) || len(selectionLines[i]) == 0 {
+		return -1
+	}
+	if x < left || x >= left+lipgloss.Width(selectionLines[i]) {
+		return -1
+	}
+	return i
+}
+
 // centerHorizontally centers text horizontally within the given width
 func centerHorizontally(text string, width int) string {
 	lines := strings.Split(text, "\n")
@@ -105,29 +138,139 @@ func centerVertically(text string, height int) string {
 	return strings.Join(result, "\n")
 }
 
+// centerView centers each line of the content in the terminal if we have
+// its dimensions
+func centerView(content string, width int, height int) string {
+	if width <= 0 || height <= 0 {
+		return content
+	}
+	content = centerHorizontally(content, width)
+	return centerVertically(content, height)
+}
+
+// placeView centers the content as a block, keeping its lines aligned with
+// each other, if we have the terminal dimensions
+func placeView(content string, width int, height int) string {
+	if width <= 0 || height <= 0 {
+		return content
+	}
+	block := lipgloss.NewStyle().Align(lipgloss.Left).Render(content)
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, block)
+}
+
 func (m model) headerView() string {
-	return fmt.Sprintf("❤️: %02d\tRemaining: %d\n\n", m.life, len(m.dungeon))
+	header := fmt.Sprintf("%s: %02d\tRemaining: %d", m.settings.glyphs().life, m.lifeView(), len(m.dungeon))
+	if m.inCampaign() {
+		header += fmt.Sprintf("\tDepth: %d", m.campaign.depth)
+	}
+	if m.settings.animations && m.flash%2 == 1 {
+		header = m.settings.styles().flash.Render(header)
+	}
+	return header + "\n\n"
 }
 
-func (m model) footerView() string {
-	s := ""
+// previewView describes how the selected move would change life. The line is
+// always present so the layout doesn't shift as the selection moves.
+func (m model) previewView() string {
+	if m.confirming {
+		return m.settings.styles().danger.Render("This move will kill you! Play it again to confirm.")
+	}
+
+	var c deck.Card
+	var fists, weapon bool
+	switch m.viewState {
+	case viewStateRoom:
+		if m.selection >= len(m.room) || !isMonster(m.room[m.selection]) {
+			return ""
+		}
+		c = m.room[m.selection]
+		fists = true
+		weapon = m.canUseWeapon(c)
+	case viewStateAttack:
+		c = m.room[m.selection]
+		fists = m.attackTypeSelection == int(withFists)
+		weapon = m.attackTypeSelection == int(withWeapon)
+	}
 
-	if m.weapon.card.Rank != 0 {
-		s += fmt.Sprintf("\n🗡  Power: %d", m.weapon.card.Rank)
+	var previews []string
+	if fists {
+		previews = append(previews, m.damagePreview("fists", m.fistDamage(c)))
 	}
+	if weapon {
+		previews = append(previews, m.damagePreview("weapon", m.weaponDamage(c)))
+		previews = append(previews, "weapon then only hits "+m.rules.limitText(m.rules.strength(c)))
+	}
+	return strings.Join(previews, ", ")
+}
 
-	if len(m.weapon.slain) > 0 {
-		s += fmt.Sprintf(" (Last slain: %d)", attackStrength(m.weapon.slain[len(m.weapon.slain)-1]))
+func (m model) damagePreview(attack string, damage int) string {
+	s := fmt.Sprintf("%s: %d %s %d", attack, m.life, m.settings.glyphs().arrow, max(0, m.life-damage))
+	if m.lethal(damage) {
+		return m.settings.styles().danger.Render(s)
 	}
-	s += "\n\n\nPress q to quit."
 	return s
 }
 
+func (m model) footerView() string {
+	s := "\n" + m.previewView()
+
+	if m.weapon.equipped() {
+		t := m.settings.styles()
+		g := m.settings.glyphs()
+		power := fmt.Sprintf("%s  Power: %d", g.weapon, m.weaponPower())
+		if limit, limited := m.weaponLimit(); limited {
+			power += ", can hit " + m.rules.limitText(limit)
+		} else {
+			power += ", can hit any monster"
+		}
+		s += "\n" + t.weapon.Render(power)
+		s += "\n" + strings.Join(weaponStackView(m.weapon, m.rules, t, g), "\n")
+	}
+
+	if len(m.relics) > 0 {
+		s += "\n\nRelics: " + m.relicsView()
+	}
+	if m.inPuzzle() {
+		s += "\n\nGoal: " + m.puzzle.goal.String()
+	}
+
+	s += "\n\n\nPress c to toggle the card counter. Press q for the menu."
+	return s
+}
+
+// weaponStackView draws the weapon card with the monsters it has slain laid
+// over it in order, like the stack on the table
+func weaponStackView(w weapon, r Rules, t theme, g glyphs) []string {
+	cards := append([]deck.Card{w.card}, w.slain...)
+
+	var top, middle, bottom string
+	for _, c := range cards {
+		top += g.cardTop
+		middle += g.cardSide + t.suit(c.Suit).Render(fmt.Sprintf("%2d%s", r.value(c), g.suit(c.Suit)))
+		bottom += g.cardBottom
+	}
+	top += g.cardEnds[0]
+	middle += g.cardEnds[1]
+	bottom += g.cardEnds[2]
+
+	return []string{top, middle, bottom}
+}
+
 func (m model) roomView() string {
-	header := fmt.Sprintf("❤️: %02d\tRemaining: %d", m.life, len(m.dungeon))
+	header := m.headerView()
 	footer := m.footerView()
+	selectionLines, _ := m.roomLines()
 
+	return layoutView(header, selectionLines, footer, m.layoutWidth(), m.height)
+}
+
+// roomLines returns the selection lines of the room view along with the
+// selection each line maps to, or -1 for spacing lines
+func (m model) roomLines() ([]string, []int) {
+	t := m.settings.styles()
+	g := m.settings.glyphs()
 	var selectionLines []string
+	var targets []int
 
 	for i, card := range m.room {
 		cursor := " "
@@ -135,16 +278,26 @@ func (m model) roomView() string {
 			cursor = ">"
 		}
 
-		var symbol string
-		switch card.Suit {
-		case deck.Heart:
-			symbol = "❤️"
-		case deck.Diamond:
-			symbol = "🗡️"
+		// Cards still being dealt are shown face down
+		if i >= m.dealtView() {
+			selectionLines = append(selectionLines, fmt.Sprintf("%s %s", cursor, g.faceDown))
+			targets = append(targets, i)
+			continue
+		}
+
+		line := fmt.Sprintf("%s %s%d", cursor, g.card(card), m.rules.value(card))
+		switch {
+		case m.lethalMonster(card):
+			line = t.danger.Render(line)
+		case card.Suit == deck.Heart:
+			line = t.potion.Render(line)
+		case card.Suit == deck.Diamond:
+			line = t.weapon.Render(line)
 		default:
-			symbol = "🐍"
+			line = t.monster.Render(line)
 		}
-		selectionLines = append(selectionLines, fmt.Sprintf("%s %s%d", cursor, symbol, attackStrength(card)))
+		selectionLines = append(selectionLines, line)
+		targets = append(targets, i)
 	}
 
 	if m.skippable {
@@ -154,29 +307,177 @@ func (m model) roomView() string {
 		}
 		selectionLines = append(selectionLines, "")
 		selectionLines = append(selectionLines, fmt.Sprintf("%s Skip this room", cursor))
+		targets = append(targets, -1, len(m.room))
 	}
 
-	return layoutView(header, selectionLines, footer, m.width, m.height)
+	return selectionLines, targets
 }
 
 func (m model) chooseAttackView() string {
-	header := fmt.Sprintf("❤️: %02d\tRemaining: %d", m.life, len(m.dungeon))
+	header := m.headerView()
 	footer := m.footerView()
+	selectionLines, _ := m.chooseAttackLines()
+
+	return layoutView(header, selectionLines, footer, m.layoutWidth(), m.height)
+}
 
+// chooseAttackLines returns the selection lines of the attack view along with
+// the attack type each line maps to, or -1 for spacing lines
+func (m model) chooseAttackLines() ([]string, []int) {
 	cursor := map[bool]string{true: ">", false: " "}
 
+	c := m.room[m.selection]
+	g := m.settings.glyphs()
+	fists := fmt.Sprintf("%s Fight with %s", cursor[m.attackTypeSelection == 0], g.fists)
+	if m.lethal(m.fistDamage(c)) {
+		fists = m.settings.styles().danger.Render(fists)
+	}
+	weapon := fmt.Sprintf("%s Fight with %s %d", cursor[m.attackTypeSelection == 1], g.weapon, m.weaponPower())
+	if m.lethal(m.weaponDamage(c)) {
+		weapon = m.settings.styles().danger.Render(weapon)
+	}
+
 	var selectionLines []string
-	selectionLines = append(selectionLines, fmt.Sprintf("%s Fight with 👊", cursor[m.attackTypeSelection == 0]))
-	selectionLines = append(selectionLines, fmt.Sprintf("%s Fight with 🗡️ %d", cursor[m.attackTypeSelection == 1], attackStrength(m.weapon.card)))
+	selectionLines = append(selectionLines, fists)
+	selectionLines = append(selectionLines, weapon)
 	selectionLines = append(selectionLines, "")
 	selectionLines = append(selectionLines, fmt.Sprintf("%s Cancel", cursor[m.attackTypeSelection == 2]))
 
-	return layoutView(header, selectionLines, footer, m.width, m.height)
+	return selectionLines, []int{int(withFists), int(withWeapon), -1, 2}
+}
+
+// counterWidth is the width of the card-counting panel
+const counterWidth = 36
+
+// layoutWidth is the width available to layoutView, leaving room for the
+// card-counting panel when it is shown
+func (m model) layoutWidth() int {
+	if !m.showCounter || m.width <= 0 {
+		return m.width
+	}
+	return max(0, m.width-counterWidth)
+}
+
+// withCounter places the card-counting panel to the right of the view when
+// it is toggled on
+func (m model) withCounter(view string) string {
+	if !m.showCounter {
+		return view
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, view, m.counterView())
+}
+
+// countByRank groups the cards matching the filter by strength, strongest
+// first, noting how many there are of each when there's more than one
+func (m model) countByRank(cards []deck.Card, f func(deck.Card) bool) string {
+	counts := map[int]int{}
+	for _, c := range cards {
+		if f(c) {
+			counts[m.rules.strength(c)]++
+		}
+	}
+
+	var groups []string
+	for strength := 14; strength >= 1; strength-- {
+		switch counts[strength] {
+		case 0:
+		case 1:
+			groups = append(groups, fmt.Sprintf("%d", strength))
+		default:
+			groups = append(groups, fmt.Sprintf("%d%s%d", strength, m.settings.glyphs().times, counts[strength]))
+		}
+	}
+	if len(groups) == 0 {
+		return "-"
+	}
+	return strings.Join(groups, " ")
+}
+
+func (m model) counterView() string {
+	isPotion := func(c deck.Card) bool { return c.Suit == deck.Heart }
+	isWeapon := func(c deck.Card) bool { return c.Suit == deck.Diamond }
+
+	g := m.settings.glyphs()
+
+	// The same cards the damage and healing left are counted from
+	ahead := slices.Concat(m.room, m.dungeon)
+
+	var lines []string
+	lines = append(lines, "In the room and dungeon")
+	lines = append(lines, g.monster+" "+m.countByRank(ahead, isMonster))
+	lines = append(lines, g.potion+" "+m.countByRank(ahead, isPotion))
+	lines = append(lines, g.weapon+" "+m.countByRank(ahead, isWeapon))
+	if m.rules.Jokers > 0 {
+		jokers := 0
+		for _, c := range ahead {
+			if isJoker(c) {
+				jokers++
+			}
+		}
+		lines = append(lines, fmt.Sprintf("%s %d", g.joker, jokers))
+	}
+	lines = append(lines, "")
+	lines = append(lines, "Discarded")
+	lines = append(lines, g.monster+" "+m.countByRank(m.discarded, isMonster))
+	lines = append(lines, g.potion+" "+m.countByRank(m.discarded, isPotion))
+	lines = append(lines, g.weapon+" "+m.countByRank(m.discarded, isWeapon))
+	lines = append(lines, "")
+	lines = append(lines, fmt.Sprintf("Damage left: %d", m.remainingDamage()))
+	lines = append(lines, fmt.Sprintf("Life + healing: %d + %d", m.life, m.remainingHealing()))
+
+	style := m.settings.styles().border.
+		Width(counterWidth-2).
+		Padding(0, 1).
+		Border(g.border)
+	return style.Render(strings.Join(lines, "\n"))
 }
 
 func (m model) gameOverView() string {
-	s := "💀 Game Over 💀\n\n"
-	s += fmt.Sprintf("Score: %d\n\n", m.score())
-	s += "Press enter to play again. Press q to quit."
-	return s
+	return m.gameOverSummary() + "\n\nPress enter to play again. Press q to return to the menu."
+}
+
+// gameOverSummary reports how the run went
+func (m model) gameOverSummary() string {
+	g := m.settings.glyphs()
+	s := g.gameOver + "\n\n"
+	if m.won() && !m.inPuzzle() || m.inPuzzle() && m.solved() {
+		s = g.victory + "\n\n"
+	}
+	if m.inPuzzle() {
+		s += fmt.Sprintf("Puzzle: %s\n", m.puzzle.name)
+		s += fmt.Sprintf("Goal: %s, %s\n\n", m.puzzle.goal, map[bool]string{true: "solved", false: "not solved"}[m.solved()])
+	}
+
+	life, penalty, bonus := m.scoreBreakdown()
+	switch {
+	case penalty > 0:
+		s += fmt.Sprintf("Score: %d (life %d %s cards left %d)\n", m.score(), life, g.minus, penalty)
+	case bonus > 0:
+		s += fmt.Sprintf("Score: %d (life %d + last potion %d)\n", m.score(), life, bonus)
+	default:
+		s += fmt.Sprintf("Score: %d\n", m.score())
+	}
+	if m.inCampaign() {
+		s += fmt.Sprintf("Depth reached: %d\n", m.campaign.depth)
+		s += fmt.Sprintf("Campaign score: %d (depth %d + score %d)\n", m.campaignScore(), m.campaign.depth, m.score())
+		s += fmt.Sprintf("Relics: %s\n", m.relicsView())
+	}
+	if !m.inPuzzle() {
+		s += fmt.Sprintf("Seed: %d\n", m.seed)
+	}
+	s += fmt.Sprintf("Rules: %s\n\n", m.rules)
+
+	sum := m.summary()
+	s += fmt.Sprintf("Rooms cleared: %d, skipped: %d\n", sum.roomsCleared, sum.roomsSkipped)
+	s += fmt.Sprintf("Potions drunk: %d, healing wasted: %d\n", sum.potionsDrunk, sum.healingWasted)
+	s += fmt.Sprintf("Monsters slain: %d with %s, %d with %s\n", sum.weaponKills, g.weapon, sum.fistKills, g.fists)
+	s += fmt.Sprintf("Biggest hit taken: %d\n", sum.biggestHit)
+	if len(sum.life) > 0 {
+		s += fmt.Sprintf("Life: %s\n", sparkline(sum.life, m.rules.MaxLife, g.sparks))
+	}
+
+	if m.err != nil {
+		s += "\n" + m.settings.styles().danger.Render(fmt.Sprintf("Couldn't record this game: %v", m.err))
+	}
+	return strings.TrimSuffix(s, "\n")
 }